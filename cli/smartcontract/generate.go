@@ -0,0 +1,123 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/urfave/cli"
+)
+
+var errNoPackage = errors.New("can't determine package name, specify it with the '--package' flag")
+
+var generateWrapperCmd = cli.Command{
+	Name:      "generate-wrapper",
+	Usage:     "generate Go wrappers for the contract RPC calls",
+	UsageText: "neo-go contract generate-wrapper --manifest <file.json> [--debug <file.debug.json>] [--out <file.go>] [--package <name>]",
+	Description: `Generates Go package with a wrapper for the contract described by the
+   manifest. Safe methods are invoked via 'invokefunction' RPC call with
+   their results decoded into Go types, other methods get functions creating
+   transactions that invoke them. Every event from the manifest ABI gets a
+   structure that can be created from RPC notification.
+
+   If debug info is given, Go names of the methods are taken from it and
+   the package is named after the contract's one (unless '--package' is
+   specified). Without '--out' the code is printed to the standard output.
+`,
+	Action: contractGenerateWrapper,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "manifest, m",
+			Usage: "Manifest input file (*.manifest.json)",
+		},
+		cli.StringFlag{
+			Name:  "debug, d",
+			Usage: "Debug info input file (*.debug.json)",
+		},
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "Output file for the generated code",
+		},
+		cli.StringFlag{
+			Name:  "package",
+			Usage: "Name of the generated package",
+		},
+	},
+}
+
+func contractGenerateWrapper(ctx *cli.Context) error {
+	manifestFile := ctx.String("manifest")
+	if len(manifestFile) == 0 {
+		return cli.NewExitError(errNoManifestFile, 1)
+	}
+	manifestBytes, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+	cfg := binding.Config{
+		Package:  ctx.String("package"),
+		Manifest: new(manifest.Manifest),
+	}
+	if err := json.Unmarshal(manifestBytes, cfg.Manifest); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+	}
+	if debugFile := ctx.String("debug"); len(debugFile) != 0 {
+		debugBytes, err := ioutil.ReadFile(debugFile)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read debug info file: %w", err), 1)
+		}
+		cfg.DebugInfo = new(compiler.DebugInfo)
+		if err := json.Unmarshal(debugBytes, cfg.DebugInfo); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to restore debug info file: %w", err), 1)
+		}
+	}
+	out := ctx.String("out")
+	if len(cfg.Package) == 0 {
+		cfg.Package = guessPackageName(cfg.DebugInfo, out)
+		if len(cfg.Package) == 0 {
+			return cli.NewExitError(errNoPackage, 1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := binding.Generate(cfg, &buf); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to generate wrapper: %w", err), 1)
+	}
+	if len(out) == 0 {
+		fmt.Print(buf.String())
+		return nil
+	}
+	if err := ioutil.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to write wrapper: %w", err), 1)
+	}
+	return nil
+}
+
+// guessPackageName returns package name for the generated code using contract
+// namespace from the debug info or output file directory name. It returns an
+// empty string if there is no suitable name.
+func guessPackageName(di *compiler.DebugInfo, out string) string {
+	if di != nil {
+		for _, m := range di.Methods {
+			if token.IsIdentifier(m.Name.Namespace) {
+				return m.Name.Namespace
+			}
+		}
+	}
+	if len(out) != 0 {
+		abs, err := filepath.Abs(out)
+		if err == nil {
+			if name := filepath.Base(filepath.Dir(abs)); token.IsIdentifier(name) {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -292,6 +292,7 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			generateWrapperCmd,
 			{
 				Name:   "inspect",
 				Usage:  "creates a user readable dump of the program instructions",
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -74,6 +76,29 @@ func newNEP5Commands() []cli.Command {
 		gasFlag,
 	}
 	multiTransferFlags = append(multiTransferFlags, options.RPC...)
+	transfersFlags := []cli.Flag{
+		flags.AddressFlag{
+			Name:  "addr",
+			Usage: "Address to get transfers for",
+		},
+		cli.Uint64Flag{
+			Name:  "start",
+			Usage: "Start timestamp (in milliseconds), 7 days before the end by default",
+		},
+		cli.Uint64Flag{
+			Name:  "end",
+			Usage: "End timestamp (in milliseconds), current time by default",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "Maximum number of transfers to get",
+		},
+		cli.IntFlag{
+			Name:  "page",
+			Usage: "Page number (starting from 0) to get when limit is specified",
+		},
+	}
+	transfersFlags = append(transfersFlags, options.RPC...)
 	return []cli.Command{
 		{
 			Name:      "balance",
@@ -82,6 +107,14 @@ func newNEP5Commands() []cli.Command {
 			Action:    getNEP5Balance,
 			Flags:     balanceFlags,
 		},
+		{
+			Name:  "transfers",
+			Usage: "get address transfers history",
+			UsageText: "transfers --rpc-endpoint <node> --timeout <time> --addr <addr>" +
+				" [--start <timestamp>] [--end <timestamp>] [--limit <n> [--page <n>]]",
+			Action: getNEP5Transfers,
+			Flags:  transfersFlags,
+		},
 		{
 			Name:      "import",
 			Usage:     "import NEP5 token to a wallet",
@@ -188,6 +221,66 @@ func getNEP5Balance(ctx *cli.Context) error {
 	return nil
 }
 
+func getNEP5Transfers(ctx *cli.Context) error {
+	addrFlag := ctx.Generic("addr").(*flags.Address)
+	if !addrFlag.IsSet {
+		return cli.NewExitError("address is not specified", 1)
+	}
+	if ctx.IsSet("page") && !ctx.IsSet("limit") {
+		return cli.NewExitError("page can't be used without limit", 1)
+	}
+
+	var start, end *uint64
+	var limit, page *int
+	if ctx.IsSet("end") || ctx.IsSet("start") || ctx.IsSet("limit") {
+		e := uint64(time.Now().Unix() * 1000)
+		if ctx.IsSet("end") {
+			e = ctx.Uint64("end")
+		}
+		s := e - uint64(7*24*time.Hour/time.Millisecond)
+		if ctx.IsSet("start") {
+			s = ctx.Uint64("start")
+		}
+		start, end = &s, &e
+	}
+	if ctx.IsSet("limit") {
+		l := ctx.Int("limit")
+		limit = &l
+		if ctx.IsSet("page") {
+			p := ctx.Int("page")
+			page = &p
+		}
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	transfers, err := c.GetNEP5Transfers(address.Uint160ToString(addrFlag.Uint160()), start, end, limit, page)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	printTransfers := func(name string, trs []result.NEP5Transfer) {
+		for _, tr := range trs {
+			fmt.Printf("%s: %s\n", name, tr.TxHash.StringLE())
+			fmt.Printf("\tTokenHash: %s\n", tr.Asset.StringLE())
+			fmt.Printf("\tAmount   : %s\n", tr.Amount)
+			if tr.Address != "" {
+				fmt.Printf("\tAddress  : %s\n", tr.Address)
+			}
+			fmt.Printf("\tBlock    : %d\n", tr.Index)
+			fmt.Printf("\tTimestamp: %d\n", tr.Timestamp)
+		}
+	}
+	printTransfers("Sent", transfers.Sent)
+	printTransfers("Received", transfers.Received)
+	return nil
+}
+
 func getMatchingToken(w *wallet.Wallet, name string) (*wallet.Token, error) {
 	switch strings.ToLower(name) {
 	case "neo":
@@ -383,7 +476,7 @@ func multiTransferNEP5(ctx *cli.Context) error {
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("invalid address: '%s'", ss[1]), 1)
 		}
-		amount, err := util.FixedNFromString(ss[2], int(token.Decimals))
+		amount, err := util.BigFixedNFromString(ss[2], int(token.Decimals))
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("invalid amount: %w", err), 1)
 		}
@@ -430,7 +523,7 @@ func transferNEP5(ctx *cli.Context) error {
 		}
 	}
 
-	amount, err := util.FixedNFromString(ctx.String("amount"), int(token.Decimals))
+	amount, err := util.BigFixedNFromString(ctx.String("amount"), int(token.Decimals))
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("invalid amount: %w", err), 1)
 	}
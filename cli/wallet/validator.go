@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -64,6 +65,13 @@ func newValidatorCommands() []cli.Command {
 				},
 			}, options.RPC...),
 		},
+		{
+			Name:      "list",
+			Usage:     "list registered candidates",
+			UsageText: "list -r <rpc> [-s <timeout>]",
+			Action:    handleList,
+			Flags:     options.RPC,
+		},
 	}
 }
 
@@ -172,6 +180,34 @@ func handleVote(ctx *cli.Context) error {
 	return nil
 }
 
+func handleList(ctx *cli.Context) error {
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	candidates, err := c.GetCandidates()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't get candidates: %w", err), 1)
+	}
+	committee, err := c.GetCommittee()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't get committee: %w", err), 1)
+	}
+	for i := range candidates {
+		pub := &candidates[i].PublicKey
+		fmt.Printf("Key: %s\n", hex.EncodeToString(pub.Bytes()))
+		fmt.Printf("\tAddress  : %s\n", pub.Address())
+		fmt.Printf("\tVotes    : %d\n", candidates[i].Votes)
+		fmt.Printf("\tValidator: %t\n", candidates[i].Active)
+		fmt.Printf("\tCommittee: %t\n", committee.Contains(pub))
+	}
+	return nil
+}
+
 func getDecryptedAccount(wall *wallet.Wallet, addr util.Uint160) (*wallet.Account, error) {
 	acc := wall.GetAccount(addr)
 	if acc == nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
 	"syscall"
@@ -225,7 +226,7 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	hash, err := c.TransferNEP5(acc, scriptHash, neoHash, 0, 0)
+	hash, err := c.TransferNEP5(acc, scriptHash, neoHash, big.NewInt(0), 0)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
@@ -296,6 +297,7 @@ func (bc *Blockchain) Run() {
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	bc.memPool.RunSubscriptions()
 	for {
 		select {
 		case <-bc.stopCh:
@@ -410,6 +412,7 @@ func (bc *Blockchain) Close() {
 	bc.addLock.Lock()
 	close(bc.stopCh)
 	<-bc.runToExitCh
+	bc.memPool.StopSubscriptions()
 	bc.addLock.Unlock()
 }
 
@@ -449,7 +452,7 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 						continue
 					}
 				} else {
-					err = bc.verifyAndPoolTx(tx, mp)
+					err = bc.verifyAndPoolTx(tx, mp.Add)
 				}
 				if err != nil {
 					return fmt.Errorf("transaction %s failed to verify: %w", tx.Hash().StringLE(), err)
@@ -572,6 +575,27 @@ func (bc *Blockchain) GetStateRoot(height uint32) (*state.MPTRootState, error) {
 	return bc.dao.GetStateRoot(height)
 }
 
+// StateHeight returns height of the latest state root in the sequence of
+// state roots stored without gaps.
+func (bc *Blockchain) StateHeight() uint32 {
+	h, _ := bc.dao.GetCurrentStateRootHeight()
+	return h
+}
+
+// GetStateProof returns proof of having key in the MPT with the specified root.
+func (bc *Blockchain) GetStateProof(root util.Uint256, key []byte) ([][]byte, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), storage.NewMemCachedStore(bc.dao.Store))
+	return tr.GetProof(key)
+}
+
+// FindStates returns key-value pairs with the given prefix from the MPT with
+// the specified root in ascending key order. Only keys greater than from are
+// returned (if from is not nil), no more than max of them (if max is positive).
+func (bc *Blockchain) FindStates(root util.Uint256, prefix, from []byte, max int) ([]storage.KeyValue, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), storage.NewMemCachedStore(bc.dao.Store))
+	return tr.Find(prefix, from, max)
+}
+
 // storeBlock performs chain update using the block given, it executes all
 // transactions with all appropriate side-effects and updates Blockchain state.
 // This is the only way to change Blockchain state.
@@ -700,7 +724,9 @@ func (bc *Blockchain) storeBlock(block *block.Block) error {
 	}
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
-	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.isTxStillRelevant(tx, txHashes) }, bc)
+	bc.memPool.RemoveStaleWithReason(func(tx *transaction.Transaction) mempool.RemovalReason {
+		return bc.staleTxReason(tx, txHashes, block.Index)
+	}, bc)
 	bc.lock.Unlock()
 
 	updateBlockHeightMetric(block.Index)
@@ -843,6 +869,46 @@ func (bc *Blockchain) ForEachNEP5Transfer(acc util.Uint160, f func(*state.NEP5Tr
 	return nil
 }
 
+// SeekNEP5Transfers executes f for each nep5 transfer of acc with timestamp
+// within [start, end] range going from the newest transfer to the oldest one.
+// Iteration stops when f returns false or an error. Transfer log batches
+// that are out of the range are skipped without decoding.
+func (bc *Blockchain) SeekNEP5Transfers(acc util.Uint160, start, end uint64, f func(*state.NEP5Transfer) (bool, error)) error {
+	balances, err := bc.dao.GetNEP5Balances(acc)
+	if err != nil {
+		return nil
+	}
+	for i := int64(balances.NextTransferBatch); i >= 0; i-- {
+		lg, err := bc.dao.GetNEP5TransferLog(acc, uint32(i))
+		if err != nil {
+			return err
+		}
+		first, ok := lg.FirstTimestamp()
+		if !ok || first > end {
+			continue
+		}
+		offsets, err := lg.Seek(start, end)
+		if err != nil {
+			return err
+		}
+		for j := len(offsets) - 1; j >= 0; j-- {
+			tr, err := lg.Get(offsets[j])
+			if err != nil {
+				return err
+			}
+			cont, err := f(tr)
+			if err != nil || !cont {
+				return err
+			}
+		}
+		if first < start {
+			// All older batches are out of range.
+			break
+		}
+	}
+	return nil
+}
+
 // GetNEP5Balances returns NEP5 balances for the acc.
 func (bc *Blockchain) GetNEP5Balances(acc util.Uint160) *state.NEP5Balances {
 	bs, err := bc.dao.GetNEP5Balances(acc)
@@ -1230,8 +1296,9 @@ var (
 )
 
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
-// to add it to the mempool given.
-func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.Pool) error {
+// to add it to the mempool using the given function (either Add or Check of
+// some pool).
+func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, addF func(*transaction.Transaction, mempool.Feer) error) error {
 	height := bc.BlockHeight()
 	if t.ValidUntilBlock <= height || t.ValidUntilBlock > height+transaction.MaxValidUntilBlockIncrement {
 		return fmt.Errorf("%w: ValidUntilBlock = %d, current height = %d", ErrTxExpired, t.ValidUntilBlock, height)
@@ -1257,7 +1324,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if err != nil {
 		return err
 	}
-	err = pool.Add(t, bc)
+	err = addF(t, bc)
 	if err != nil {
 		switch {
 		case errors.Is(err, mempool.ErrConflict):
@@ -1303,23 +1370,29 @@ func (bc *Blockchain) verifyTxAttributes(tx *transaction.Transaction) error {
 	return nil
 }
 
-// isTxStillRelevant is a callback for mempool transaction filtering after the
-// new block addition. It returns false for transactions added by the new block
-// (passed via txHashes) and does witness reverification for non-standard
-// contracts. It operates under the assumption that full transaction verification
-// was already done so we don't need to check basic things like size, input/output
-// correctness, presence in blocks before the new one, etc.
-func (bc *Blockchain) isTxStillRelevant(t *transaction.Transaction, txHashes []util.Uint256) bool {
+// staleTxReason is a callback for mempool transaction filtering after the
+// new block addition. It returns mempool.ReasonIncluded for transactions added
+// by the new block (passed via txHashes), mempool.ReasonExpired for
+// transactions that can't be included into subsequent blocks and does witness
+// reverification for non-standard contracts, mempool.ReasonNone is returned
+// for transactions that are still relevant. It operates under the assumption
+// that full transaction verification was already done so we don't need to
+// check basic things like size, input/output correctness, presence in blocks
+// before the new one, etc.
+func (bc *Blockchain) staleTxReason(t *transaction.Transaction, txHashes []util.Uint256, height uint32) mempool.RemovalReason {
 	var recheckWitness bool
 
 	index := sort.Search(len(txHashes), func(i int) bool {
 		return txHashes[i].CompareTo(t.Hash()) >= 0
 	})
 	if index < len(txHashes) && txHashes[index].Equals(t.Hash()) {
-		return false
+		return mempool.ReasonIncluded
+	}
+	if t.ValidUntilBlock <= height {
+		return mempool.ReasonExpired
 	}
 	if err := bc.verifyTxAttributes(t); err != nil {
-		return false
+		return mempool.ReasonInvalid
 	}
 	for i := range t.Scripts {
 		if !vm.IsStandardContract(t.Scripts[i].VerificationScript) {
@@ -1327,11 +1400,10 @@ func (bc *Blockchain) isTxStillRelevant(t *transaction.Transaction, txHashes []u
 			break
 		}
 	}
-	if recheckWitness {
-		return bc.verifyTxWitnesses(t, nil) == nil
+	if recheckWitness && bc.verifyTxWitnesses(t, nil) != nil {
+		return mempool.ReasonInvalid
 	}
-	return true
-
+	return mempool.ReasonNone
 }
 
 // AddStateRoot add new (possibly unverified) state root to the blockchain.
@@ -1414,7 +1486,16 @@ func (bc *Blockchain) VerifyTx(t *transaction.Transaction) error {
 	var mp = mempool.New(1)
 	bc.lock.RLock()
 	defer bc.lock.RUnlock()
-	return bc.verifyAndPoolTx(t, mp)
+	return bc.verifyAndPoolTx(t, mp.Check)
+}
+
+// VerifyTxAgainstPool verifies whether transaction is bonafide or not relative
+// to the current blockchain state and the contents of the node's mempool. The
+// transaction is never added to the mempool.
+func (bc *Blockchain) VerifyTxAgainstPool(t *transaction.Transaction) error {
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	return bc.verifyAndPoolTx(t, bc.memPool.Check)
 }
 
 // PoolTx verifies and tries to add given transaction into the mempool. If not
@@ -1431,7 +1512,7 @@ func (bc *Blockchain) PoolTx(t *transaction.Transaction, pools ...*mempool.Pool)
 	if len(pools) == 1 {
 		pool = pools[0]
 	}
-	return bc.verifyAndPoolTx(t, pool)
+	return bc.verifyAndPoolTx(t, pool.Add)
 }
 
 //GetStandByValidators returns validators from the configuration.
@@ -1449,6 +1530,17 @@ func (bc *Blockchain) GetValidators() ([]*keys.PublicKey, error) {
 	return bc.contracts.NEO.GetValidatorsInternal(bc, bc.dao)
 }
 
+// GetCommittee returns current committee members sorted by public key.
+func (bc *Blockchain) GetCommittee() (keys.PublicKeys, error) {
+	pubs, err := bc.contracts.NEO.GetCommitteeMembers(bc, bc.dao)
+	if err != nil {
+		return nil, err
+	}
+	pubs = pubs.Copy()
+	sort.Sort(pubs)
+	return pubs, nil
+}
+
 // GetNextBlockValidators returns next block validators.
 func (bc *Blockchain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 	return bc.contracts.NEO.GetNextBlockValidatorsInternal(bc, bc.dao)
@@ -1467,6 +1559,49 @@ func (bc *Blockchain) GetTestVM(tx *transaction.Transaction) *vm.VM {
 	return vm
 }
 
+// GetTestHistoricVM returns a VM set up for a test run of some script using
+// contract storage state from the MPT with the specified root.
+func (bc *Blockchain) GetTestHistoricVM(tx *transaction.Transaction, root util.Uint256) *vm.VM {
+	d := dao.NewHistoric(bc.dao, root)
+	systemInterop := bc.newInteropContext(trigger.Application, d, nil, tx)
+	vm := systemInterop.SpawnVM()
+	vm.SetPriceGetter(getPrice)
+	return vm
+}
+
+// TraceTestScript runs the given script in a test VM (using contract storage
+// state from the MPT with the specified root if it's not nil) with the given
+// GAS limit and returns this VM along with execution trace collected.
+func (bc *Blockchain) TraceTestScript(tx *transaction.Transaction, root *util.Uint256, script []byte, gasLimit int64) (*vm.VM, *state.ExecutionTrace) {
+	var d dao.DAO = bc.dao
+	if root != nil {
+		d = dao.NewHistoric(bc.dao, *root)
+	}
+	ic := bc.newInteropContext(trigger.Application, d, nil, tx)
+	ic.Tracer = interop.NewTracer()
+	v := ic.SpawnVM()
+	v.SetPriceGetter(getPrice)
+	v.GasLimit = gasLimit
+	v.LoadScriptWithFlags(script, smartcontract.All)
+	_ = ic.Tracer.Run(ic)
+	return v, ic.Tracer.Trace()
+}
+
+// GetTestVerificationVM returns a VM set up for a test run of the given witness
+// for the given hash with tx used as a script container. If the witness has
+// no verification script, the verify method of the contract with the given
+// hash is used, invocation script is expected to push its arguments onto the
+// stack then.
+func (bc *Blockchain) GetTestVerificationVM(tx *transaction.Transaction, hash util.Uint160, w *transaction.Witness) (*vm.VM, error) {
+	ic := bc.newInteropContext(trigger.Verification, bc.dao, nil, tx)
+	vm := ic.SpawnVM()
+	vm.SetPriceGetter(getPrice)
+	if err := initVerificationVM(ic, hash, w, nil); err != nil {
+		return nil, err
+	}
+	return vm, nil
+}
+
 // Various witness verification errors.
 var (
 	ErrWitnessHashMismatch         = errors.New("witness hash mismatch")
@@ -1475,6 +1610,23 @@ var (
 	ErrInvalidVerificationContract = errors.New("verification contract is missing `verify` method")
 )
 
+// WitnessError is returned when transaction witness check fails, Index is
+// the index of the signer whose witness is invalid.
+type WitnessError struct {
+	Index int
+	Err   error
+}
+
+// Error implements error interface.
+func (e *WitnessError) Error() string {
+	return fmt.Sprintf("witness #%d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying witness check error.
+func (e *WitnessError) Unwrap() error {
+	return e.Err
+}
+
 // initVerificationVM initializes VM for witness check.
 func initVerificationVM(ic *interop.Context, hash util.Uint160, witness *transaction.Witness, keyCache map[string]*keys.PublicKey) error {
 	var offset int
@@ -1585,7 +1737,7 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	for i := range t.Signers {
 		err := bc.verifyHashAgainstScript(t.Signers[i].Account, &t.Scripts[i], interopCtx, false, t.NetworkFee)
 		if err != nil {
-			return fmt.Errorf("witness #%d: %w", i, err)
+			return &WitnessError{Index: i, Err: err}
 		}
 	}
 
@@ -1613,6 +1765,33 @@ func (bc *Blockchain) UtilityTokenHash() util.Uint160 {
 	return bc.contracts.GAS.Hash
 }
 
+// GetNatives returns the list of native contracts with their metadata,
+// methods are sorted by name.
+func (bc *Blockchain) GetNatives() []state.NativeContract {
+	res := make([]state.NativeContract, 0, len(bc.contracts.Contracts))
+	for _, c := range bc.contracts.Contracts {
+		md := c.Metadata()
+		nc := state.NativeContract{
+			ID:       md.ContractID,
+			Hash:     md.Hash,
+			Name:     md.Name,
+			Script:   md.Script,
+			Manifest: md.Manifest,
+			Methods:  make([]state.NativeMethod, 0, len(md.Methods)),
+		}
+		for name, m := range md.Methods {
+			nc.Methods = append(nc.Methods, state.NativeMethod{
+				Name:          name,
+				Price:         m.Price,
+				RequiredFlags: m.RequiredFlags,
+			})
+		}
+		sort.Slice(nc.Methods, func(i, j int) bool { return nc.Methods[i].Name < nc.Methods[j].Name })
+		res = append(res, nc)
+	}
+	return res
+}
+
 func hashAndIndexToBytes(h util.Uint256, index uint32) []byte {
 	buf := io.NewBufBinWriter()
 	buf.WriteBytes(h.BytesLE())
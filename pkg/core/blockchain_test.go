@@ -377,6 +377,47 @@ func TestVerifyTx(t *testing.T) {
 	})
 }
 
+func TestGetTestVerificationVM(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	cs, csInvalid := getTestContractState()
+	require.NoError(t, bc.dao.PutContractState(cs))
+	require.NoError(t, bc.dao.PutContractState(csInvalid))
+
+	tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.RET)}, 0)
+	check := func(t *testing.T, invocation []byte, expected bool) {
+		v, err := bc.GetTestVerificationVM(tx, cs.ScriptHash(), &transaction.Witness{InvocationScript: invocation})
+		require.NoError(t, err)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		require.Equal(t, expected, v.Estack().Pop().Bool())
+	}
+	t.Run("Valid", func(t *testing.T) { check(t, []byte{byte(opcode.PUSH4)}, true) })
+	t.Run("Invalid", func(t *testing.T) { check(t, []byte{byte(opcode.PUSH3)}, false) })
+	t.Run("MissingContract", func(t *testing.T) {
+		h := cs.ScriptHash()
+		h[0] = ^h[0]
+		_, err := bc.GetTestVerificationVM(tx, h, &transaction.Witness{})
+		require.True(t, errors.Is(err, ErrUnknownVerificationContract))
+	})
+	t.Run("NoVerifyMethod", func(t *testing.T) {
+		_, err := bc.GetTestVerificationVM(tx, csInvalid.ScriptHash(), &transaction.Witness{})
+		require.True(t, errors.Is(err, ErrInvalidVerificationContract))
+	})
+	t.Run("VerificationScript", func(t *testing.T) {
+		verif := []byte{byte(opcode.PUSH1)}
+		w := &transaction.Witness{VerificationScript: verif}
+		v, err := bc.GetTestVerificationVM(tx, hash.Hash160(verif), w)
+		require.NoError(t, err)
+		require.NoError(t, v.Run())
+		require.True(t, v.Estack().Pop().Bool())
+
+		_, err = bc.GetTestVerificationVM(tx, util.Uint160{}, w)
+		require.True(t, errors.Is(err, ErrWitnessHashMismatch))
+	})
+}
+
 func TestVerifyHashAgainstScript(t *testing.T) {
 	bc := newTestChain(t)
 	defer bc.Close()
@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -27,11 +28,14 @@ type Blockchainer interface {
 	Close()
 	HeaderHeight() uint32
 	GetBlock(hash util.Uint256) (*block.Block, error)
+	GetCommittee() (keys.PublicKeys, error)
 	GetContractState(hash util.Uint160) *state.Contract
 	GetContractScriptHash(id int32) (util.Uint160, error)
 	GetEnrollments() ([]state.Validator, error)
 	GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
+	FindStates(root util.Uint256, prefix, from []byte, max int) ([]storage.KeyValue, error)
 	ForEachNEP5Transfer(util.Uint160, func(*state.NEP5Transfer) error) error
+	SeekNEP5Transfers(acc util.Uint160, start, end uint64, f func(*state.NEP5Transfer) (bool, error)) error
 	GetHeaderHash(int) util.Uint256
 	GetHeader(hash util.Uint256) (*block.Header, error)
 	CurrentHeaderHash() util.Uint256
@@ -40,24 +44,31 @@ type Blockchainer interface {
 	HasTransaction(util.Uint256) bool
 	GetAppExecResult(util.Uint256) (*state.AppExecResult, error)
 	GetNextBlockValidators() ([]*keys.PublicKey, error)
+	GetNatives() []state.NativeContract
 	GetNEP5Balances(util.Uint160) *state.NEP5Balances
 	GetValidators() ([]*keys.PublicKey, error)
 	GetStandByCommittee() keys.PublicKeys
 	GetStandByValidators() keys.PublicKeys
+	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	GetStateRoot(height uint32) (*state.MPTRootState, error)
 	GetStorageItem(id int32, key []byte) *state.StorageItem
 	GetStorageItems(id int32) (map[string]*state.StorageItem, error)
 	GetTestVM(tx *transaction.Transaction) *vm.VM
+	GetTestHistoricVM(tx *transaction.Transaction, root util.Uint256) *vm.VM
+	GetTestVerificationVM(tx *transaction.Transaction, hash util.Uint160, w *transaction.Witness) (*vm.VM, error)
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
 	mempool.Feer // fee interface
 	GetMaxBlockSize() uint32
 	GetMaxBlockSystemFee() int64
 	PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error
+	StateHeight() uint32
 	SubscribeForBlocks(ch chan<- *block.Block)
 	SubscribeForExecutions(ch chan<- *state.AppExecResult)
 	SubscribeForNotifications(ch chan<- *state.NotificationEvent)
 	SubscribeForTransactions(ch chan<- *transaction.Transaction)
+	TraceTestScript(tx *transaction.Transaction, root *util.Uint256, script []byte, gasLimit int64) (*vm.VM, *state.ExecutionTrace)
 	VerifyTx(*transaction.Transaction) error
+	VerifyTxAgainstPool(*transaction.Transaction) error
 	VerifyWitness(util.Uint160, crypto.Verifiable, *transaction.Witness, int64) error
 	GetMemPool() *mempool.Pool
 	UnsubscribeFromBlocks(ch chan<- *block.Block)
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrReadOnly is returned on attempt to persist changes made to read-only DAO.
+var ErrReadOnly = errors.New("DAO is read-only")
+
+// Historic is a read-only data access object that takes contract storage items
+// from the MPT with some (possibly old) state root, while everything else
+// (including contract states) is taken from the underlying DAO. Changes made
+// to it are kept in memory and can't be persisted.
+type Historic struct {
+	*Simple
+}
+
+// NewHistoric returns new Historic DAO using MPT with the given root.
+func NewHistoric(d *Simple, root util.Uint256) *Historic {
+	st := storage.NewMemCachedStore(d.Store)
+	return &Historic{&Simple{
+		MPT:     mpt.NewTrie(mpt.NewHashNode(root), st),
+		Store:   st,
+		network: d.network,
+	}}
+}
+
+// GetWrapped implements DAO interface. Wrapped DAO has its own trie, so that
+// changes made to it don't affect h.
+func (h *Historic) GetWrapped() DAO {
+	// Nodes are flushed into h's in-memory store, so that the new trie can
+	// get them from there (this store is never persisted).
+	h.MPT.Flush()
+	var root mpt.Node
+	if r := h.MPT.StateRoot(); !r.Equals(util.Uint256{}) {
+		root = mpt.NewHashNode(r)
+	}
+	st := storage.NewMemCachedStore(h.Store)
+	return &Historic{&Simple{
+		MPT:     mpt.NewTrie(root, st),
+		Store:   st,
+		network: h.network,
+	}}
+}
+
+// Persist implements DAO interface, it always returns ErrReadOnly.
+func (h *Historic) Persist() (int, error) {
+	return 0, ErrReadOnly
+}
+
+// GetStorageItem returns StorageItem from the MPT if it exists there. It
+// panics if the MPT can't be read (e.g. some of its nodes are missing), so
+// that the invocation using it fails instead of getting wrong data.
+func (h *Historic) GetStorageItem(id int32, key []byte) *state.StorageItem {
+	b, err := h.MPT.Get(makeStorageItemKey(id, key)[1:])
+	if err != nil {
+		if errors.Is(err, mpt.ErrNotFound) {
+			return nil
+		}
+		panic(fmt.Errorf("failed to get storage item from MPT: %w", err))
+	}
+	r := io.NewBinReaderFromBuf(b)
+
+	si := &state.StorageItem{}
+	si.DecodeBinary(r)
+	if r.Err != nil {
+		return nil
+	}
+
+	return si
+}
+
+// GetStorageItems returns all storage items for a given id from the MPT.
+func (h *Historic) GetStorageItems(id int32) (map[string]*state.StorageItem, error) {
+	return h.GetStorageItemsWithPrefix(id, nil)
+}
+
+// GetStorageItemsWithPrefix returns all storage items with given id for a
+// given prefix from the MPT.
+func (h *Historic) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]*state.StorageItem, error) {
+	lookupKey := append(makeStorageItemKey(id, nil)[1:], prefix...)
+	kvs, err := h.MPT.Find(lookupKey, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var siMap = make(map[string]*state.StorageItem, len(kvs))
+	for _, kv := range kvs {
+		r := io.NewBinReaderFromBuf(kv.Value)
+		si := &state.StorageItem{}
+		si.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		// Cut prefix and id.
+		siMap[string(kv.Key[len(lookupKey):])] = si
+	}
+	return siMap, nil
+}
@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricDao(t *testing.T) {
+	pdao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet)
+	require.NoError(t, pdao.PutStorageItem(1, []byte{1}, &state.StorageItem{Value: []byte{0x11}}))
+	require.NoError(t, pdao.PutStorageItem(1, []byte{2}, &state.StorageItem{Value: []byte{0x21}}))
+	pdao.MPT.Flush()
+	oldRoot := pdao.MPT.StateRoot()
+
+	require.NoError(t, pdao.PutStorageItem(1, []byte{1}, &state.StorageItem{Value: []byte{0x12}}))
+	require.NoError(t, pdao.DeleteStorageItem(1, []byte{2}))
+	require.NoError(t, pdao.PutStorageItem(1, []byte{3}, &state.StorageItem{Value: []byte{0x31}}))
+	pdao.MPT.Flush()
+
+	hdao := NewHistoric(pdao, oldRoot)
+	require.Equal(t, []byte{0x11}, hdao.GetStorageItem(1, []byte{1}).Value)
+	require.Equal(t, []byte{0x21}, hdao.GetStorageItem(1, []byte{2}).Value)
+	require.Nil(t, hdao.GetStorageItem(1, []byte{3}))
+	require.Nil(t, hdao.GetStorageItem(2, []byte{1}))
+
+	items, err := hdao.GetStorageItems(1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*state.StorageItem{
+		string([]byte{1}): {Value: []byte{0x11}},
+		string([]byte{2}): {Value: []byte{0x21}},
+	}, items)
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		cdao := NewCached(hdao)
+		require.NoError(t, cdao.PutStorageItem(1, []byte{3}, &state.StorageItem{Value: []byte{0x32}}))
+		require.Equal(t, []byte{0x32}, cdao.GetStorageItem(1, []byte{3}).Value)
+		_, err := cdao.Persist()
+		require.Error(t, err)
+
+		require.Equal(t, []byte{0x31}, pdao.GetStorageItem(1, []byte{3}).Value)
+		require.Nil(t, hdao.GetStorageItem(1, []byte{3}))
+	})
+	t.Run("wrapped", func(t *testing.T) {
+		wdao := hdao.GetWrapped()
+		require.Equal(t, []byte{0x11}, wdao.GetStorageItem(1, []byte{1}).Value)
+		require.NoError(t, wdao.PutStorageItem(1, []byte{1}, &state.StorageItem{Value: []byte{0x13}}))
+		require.NoError(t, wdao.DeleteStorageItem(1, []byte{2}))
+		require.Equal(t, []byte{0x13}, wdao.GetStorageItem(1, []byte{1}).Value)
+		require.Nil(t, wdao.GetStorageItem(1, []byte{2}))
+
+		require.Equal(t, []byte{0x11}, hdao.GetStorageItem(1, []byte{1}).Value)
+		require.Equal(t, []byte{0x21}, hdao.GetStorageItem(1, []byte{2}).Value)
+		require.Equal(t, oldRoot, hdao.MPT.StateRoot())
+	})
+	t.Run("wrapped empty", func(t *testing.T) {
+		edao := NewHistoric(pdao, util.Uint256{}).GetWrapped()
+		require.Nil(t, edao.GetStorageItem(1, []byte{1}))
+		require.NoError(t, edao.PutStorageItem(1, []byte{1}, &state.StorageItem{Value: []byte{0x14}}))
+		require.Equal(t, []byte{0x14}, edao.GetStorageItem(1, []byte{1}).Value)
+	})
+	t.Run("unknown root", func(t *testing.T) {
+		udao := NewHistoric(pdao, util.Uint256{1, 2, 3})
+		require.Panics(t, func() { udao.GetStorageItem(1, []byte{1}) })
+	})
+}
@@ -38,6 +38,9 @@ type Context struct {
 	Invocations   map[util.Uint160]int
 	VM            *vm.VM
 	Functions     [][]Function
+	// Tracer collects execution trace, it's only set for traced test
+	// invocations.
+	Tracer *Tracer
 }
 
 // NewContext returns new interop context.
@@ -0,0 +1,190 @@
+package interop
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Tracer collects execution trace of the script. It's attached to the
+// Context for test invocations only, interop functions report contract calls
+// and logs to it while Run tracks call frames, storage changes and the
+// exception location.
+type Tracer struct {
+	trace  state.ExecutionTrace
+	frames []tracedFrame
+}
+
+// tracedFrame is a call frame that hasn't returned yet.
+type tracedFrame struct {
+	frame *state.CallFrame
+	// depth is the invocation stack size right after the frame is loaded,
+	// frame returns when the stack becomes smaller than that.
+	depth int
+	// gas is the amount of GAS consumed at the moment of frame entry.
+	gas int64
+}
+
+// NewTracer returns a new empty Tracer.
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// Trace returns collected execution trace.
+func (t *Tracer) Trace() *state.ExecutionTrace {
+	return &t.trace
+}
+
+// EnterContract records contract call, it must be invoked right after the
+// contract script is loaded into the VM.
+func (t *Tracer) EnterContract(v *vm.VM, h util.Uint160, method string) {
+	f := &state.CallFrame{
+		ScriptHash: h,
+		Method:     method,
+	}
+	if len(t.frames) == 0 {
+		t.trace.Calls = f
+	} else {
+		parent := t.frames[len(t.frames)-1].frame
+		parent.Calls = append(parent.Calls, f)
+	}
+	t.frames = append(t.frames, tracedFrame{
+		frame: f,
+		depth: v.Istack().Len(),
+		gas:   v.GasConsumed(),
+	})
+}
+
+// StoragePut records storage item change done by the contract h, oldValue
+// is nil if there was no such item before.
+func (t *Tracer) StoragePut(h util.Uint160, id int32, key, oldValue, newValue []byte) {
+	t.trace.Storage = append(t.trace.Storage, state.StorageChange{
+		ScriptHash: h,
+		ID:         id,
+		Key:        copyBytes(key),
+		OldValue:   copyBytes(oldValue),
+		NewValue:   copyBytes(newValue),
+	})
+}
+
+// StorageDelete records storage item deletion done by the contract h.
+func (t *Tracer) StorageDelete(h util.Uint160, id int32, key, oldValue []byte) {
+	t.trace.Storage = append(t.trace.Storage, state.StorageChange{
+		ScriptHash: h,
+		ID:         id,
+		Key:        copyBytes(key),
+		OldValue:   copyBytes(oldValue),
+		Deleted:    true,
+	})
+}
+
+// Log records the message logged by the contract h.
+func (t *Tracer) Log(h util.Uint160, msg string) {
+	t.trace.Logs = append(t.trace.Logs, state.LogEvent{
+		ScriptHash: h,
+		Message:    msg,
+	})
+}
+
+// Run executes the script loaded into ic.VM instruction by instruction
+// tracking call frames. It's similar to vm.Run, but breakpoints are ignored.
+// Storage changes are collected by wrapping ic.DAO, so that both contracts
+// and native contracts changes get into the trace. In case of FAULT the
+// location of the failing instruction is saved to the trace. Notifications
+// emitted are copied to the trace after the execution.
+func (t *Tracer) Run(ic *Context) error {
+	v := ic.VM
+	if !v.Ready() {
+		return errors.New("no program loaded")
+	}
+	ic.DAO.DAO = &tracedDAO{DAO: ic.DAO.DAO, ic: ic, tracer: t}
+	t.EnterContract(v, v.GetCurrentScriptHash(), "")
+
+	var err error
+	for v.State() == vm.NoneState {
+		err = v.Step()
+		if err != nil {
+			break
+		}
+		t.leaveFrames(v, v.Istack().Len())
+	}
+	if v.HasFailed() {
+		if err == nil {
+			err = errors.New("VM has failed")
+		}
+		t.trace.Exception = newExceptionLocation(v, err)
+	}
+	t.leaveFrames(v, 0)
+	t.trace.Notifications = ic.Notifications
+	return err
+}
+
+// leaveFrames closes all frames that don't fit into the invocation stack of
+// the given size.
+func (t *Tracer) leaveFrames(v *vm.VM, size int) {
+	for i := len(t.frames) - 1; i >= 0 && t.frames[i].depth > size; i-- {
+		t.frames[i].frame.GasConsumed = v.GasConsumed() - t.frames[i].gas
+		t.frames = t.frames[:i]
+	}
+}
+
+// tracedDAO reports storage item changes to the tracer, the contract making
+// the change is the one currently executed by the VM.
+type tracedDAO struct {
+	dao.DAO
+	ic     *Context
+	tracer *Tracer
+}
+
+// PutStorageItem implements dao.DAO interface.
+func (d *tracedDAO) PutStorageItem(id int32, key []byte, si *state.StorageItem) error {
+	old := d.getValue(id, key)
+	if err := d.DAO.PutStorageItem(id, key, si); err != nil {
+		return err
+	}
+	d.tracer.StoragePut(d.ic.VM.GetCurrentScriptHash(), id, key, old, si.Value)
+	return nil
+}
+
+// DeleteStorageItem implements dao.DAO interface.
+func (d *tracedDAO) DeleteStorageItem(id int32, key []byte) error {
+	old := d.getValue(id, key)
+	if err := d.DAO.DeleteStorageItem(id, key); err != nil {
+		return err
+	}
+	d.tracer.StorageDelete(d.ic.VM.GetCurrentScriptHash(), id, key, old)
+	return nil
+}
+
+func (d *tracedDAO) getValue(id int32, key []byte) []byte {
+	if si := d.DAO.GetStorageItem(id, key); si != nil {
+		return si.Value
+	}
+	return nil
+}
+
+func newExceptionLocation(v *vm.VM, err error) *state.ExceptionLocation {
+	loc := &state.ExceptionLocation{Message: err.Error()}
+	ctx := v.Context()
+	if ctx == nil {
+		return loc
+	}
+	loc.ScriptHash = ctx.ScriptHash()
+	loc.IP = ctx.IP()
+	loc.Opcode = opcode.RET // Implicit return at the end of the script.
+	if loc.IP < ctx.LenInstr() {
+		_, loc.Opcode = ctx.CurrInstr()
+	}
+	return loc
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
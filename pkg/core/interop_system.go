@@ -294,6 +294,9 @@ func runtimeLog(ic *interop.Context) error {
 	if len(state) > MaxNotificationSize {
 		return fmt.Errorf("message length shouldn't exceed %v", MaxNotificationSize)
 	}
+	if ic.Tracer != nil {
+		ic.Tracer.Log(ic.VM.GetCurrentScriptHash(), state)
+	}
 	msg := fmt.Sprintf("%q", state)
 	ic.Log.Info("runtime log",
 		zap.Stringer("script", ic.VM.GetCurrentScriptHash()),
@@ -503,6 +506,9 @@ func contractCallExInternal(ic *interop.Context, h []byte, name string, args []s
 
 	ic.Invocations[u]++
 	ic.VM.LoadScriptWithHash(cs.Script, u, ic.VM.Context().GetCallFlags()&f)
+	if ic.Tracer != nil {
+		ic.Tracer.EnterContract(ic.VM, u, name)
+	}
 	var isNative bool
 	for i := range ic.Natives {
 		if ic.Natives[i].Metadata().Hash.Equals(u) {
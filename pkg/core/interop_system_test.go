@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
@@ -8,15 +9,18 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/callback"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -941,3 +945,111 @@ func TestSyscallCallback(t *testing.T) {
 		})
 	})
 }
+
+func TestTracer(t *testing.T) {
+	_, ic, bc := createVM(t)
+	defer bc.Close()
+
+	w := io.NewBufBinWriter()
+	emit.String(w.BinWriter, "v")
+	emit.String(w.BinWriter, "k")
+	emit.Syscall(w.BinWriter, interopnames.SystemStorageGetContext)
+	emit.Syscall(w.BinWriter, interopnames.SystemStoragePut)
+	emit.String(w.BinWriter, "hi")
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog)
+	emit.String(w.BinWriter, "k")
+	emit.Syscall(w.BinWriter, interopnames.SystemStorageGetContext)
+	emit.Syscall(w.BinWriter, interopnames.SystemStorageDelete)
+	emit.Opcode(w.BinWriter, opcode.RET)
+	failOffset := w.Len()
+	emit.Opcode(w.BinWriter, opcode.ABORT)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	h := hash.Hash160(script)
+	m := manifest.NewManifest(h)
+	m.Features = smartcontract.HasStorage
+	m.ABI.Methods = []manifest.Method{
+		{Name: "put", Offset: 0, ReturnType: smartcontract.VoidType},
+		{Name: "fail", Offset: failOffset, ReturnType: smartcontract.VoidType},
+	}
+	cs := &state.Contract{Script: script, Manifest: *m, ID: 42}
+	require.NoError(t, ic.DAO.PutContractState(cs))
+
+	run := func(t *testing.T, method string) (*vm.VM, *state.ExecutionTrace, []byte) {
+		w := io.NewBufBinWriter()
+		emit.AppCallWithOperationAndArgs(w.BinWriter, h, method)
+		require.NoError(t, w.Err)
+		entry := w.Bytes()
+
+		ic.Notifications = ic.Notifications[:0]
+		ic.Tracer = interop.NewTracer()
+		v := ic.SpawnVM()
+		v.SetPriceGetter(getPrice)
+		v.LoadScriptWithFlags(entry, smartcontract.All)
+		err := ic.Tracer.Run(ic)
+		if method == "fail" {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+		return v, ic.Tracer.Trace(), entry
+	}
+
+	t.Run("Good", func(t *testing.T) {
+		v, tr, entry := run(t, "put")
+		require.Equal(t, vm.HaltState, v.State())
+		require.NotNil(t, tr.Calls)
+		require.Equal(t, hash.Hash160(entry), tr.Calls.ScriptHash)
+		require.Equal(t, "", tr.Calls.Method)
+		require.Equal(t, v.GasConsumed(), tr.Calls.GasConsumed)
+		require.Equal(t, 1, len(tr.Calls.Calls))
+		call := tr.Calls.Calls[0]
+		require.Equal(t, h, call.ScriptHash)
+		require.Equal(t, "put", call.Method)
+		require.True(t, call.GasConsumed > 0)
+		require.True(t, call.GasConsumed < tr.Calls.GasConsumed)
+		require.Equal(t, []state.StorageChange{
+			{ScriptHash: h, ID: 42, Key: []byte("k"), NewValue: []byte("v")},
+			{ScriptHash: h, ID: 42, Key: []byte("k"), OldValue: []byte("v"), Deleted: true},
+		}, tr.Storage)
+		require.Equal(t, []state.LogEvent{{ScriptHash: h, Message: "hi"}}, tr.Logs)
+		require.Nil(t, tr.Exception)
+	})
+	t.Run("Fault", func(t *testing.T) {
+		v, tr, _ := run(t, "fail")
+		require.Equal(t, vm.FaultState, v.State())
+		require.Equal(t, 1, len(tr.Calls.Calls))
+		require.Equal(t, "fail", tr.Calls.Calls[0].Method)
+		require.Equal(t, v.GasConsumed(), tr.Calls.GasConsumed)
+		require.NotNil(t, tr.Exception)
+		require.Equal(t, h, tr.Exception.ScriptHash)
+		require.Equal(t, failOffset, tr.Exception.IP)
+		require.Equal(t, opcode.ABORT, tr.Exception.Opcode)
+		require.NotEqual(t, "", tr.Exception.Message)
+	})
+}
+
+func TestTracerNative(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	gasHash := bc.contracts.GAS.Hash
+	to := util.Uint160{1, 2, 3}
+	tx := newNEP5Transfer(gasHash, neoOwner, to, 1)
+	addSigners(tx)
+	v, tr := bc.TraceTestScript(tx, nil, tx.Script, 100000000)
+	require.Equal(t, vm.HaltState, v.State())
+
+	// Both balances are changed by GAS contract itself.
+	require.Equal(t, 2, len(tr.Storage))
+	for _, sc := range tr.Storage {
+		require.Equal(t, gasHash, sc.ScriptHash)
+		require.Equal(t, bc.contracts.GAS.ContractID, sc.ID)
+		require.False(t, sc.Deleted)
+		require.NotNil(t, sc.NewValue)
+	}
+	require.NotNil(t, tr.Storage[0].OldValue)
+	require.True(t, bytes.HasSuffix(tr.Storage[1].Key, to.BytesBE()))
+	require.Nil(t, tr.Storage[1].OldValue)
+}
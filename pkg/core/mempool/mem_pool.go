@@ -9,6 +9,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/atomic"
 )
 
 var (
@@ -53,6 +54,20 @@ type Pool struct {
 
 	capacity   int
 	feePerByte int64
+
+	subscriptionsOn atomic.Bool
+	stopCh          chan struct{}
+	// eventQueue contains events not yet sent to subscribers, it's filled
+	// with the pool lock held (to keep events ordered) and drained by the
+	// dispatcher that is woken up via events channel.
+	eventsLock sync.Mutex
+	eventQueue []Event
+	// eventsMissed is set when EventsMissed is queued, events are dropped
+	// until the dispatcher takes the queue then.
+	eventsMissed bool
+	events       chan struct{}
+	subCh        chan chan<- Event // there are no other events
+	unsubCh      chan chan<- Event
 }
 
 func (p items) Len() int           { return len(p) }
@@ -188,6 +203,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
 		unlucky := mp.verifiedTxes[len(mp.verifiedTxes)-1]
 		delete(mp.verifiedMap, unlucky.txn.Hash())
 		mp.verifiedTxes[len(mp.verifiedTxes)-1] = pItem
+		mp.emit(TransactionRemoved, unlucky.txn, ReasonEvicted)
 	} else {
 		mp.verifiedTxes = append(mp.verifiedTxes, pItem)
 	}
@@ -197,6 +213,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
 	}
 	// we already checked balance in checkTxConflicts, so don't need to check again
 	mp.tryAddSendersFee(pItem.txn, fee, false)
+	mp.emit(TransactionAdded, pItem.txn, ReasonNone)
 
 	updateMempoolMetrics(len(mp.verifiedTxes))
 	mp.lock.Unlock()
@@ -223,6 +240,7 @@ func (mp *Pool) Remove(hash util.Uint256) {
 		senderFee := mp.fees[it.txn.Sender()]
 		senderFee.feeSum.Sub(senderFee.feeSum, big.NewInt(it.txn.SystemFee+it.txn.NetworkFee))
 		mp.fees[it.txn.Sender()] = senderFee
+		mp.emit(TransactionRemoved, it.txn, ReasonRemoved)
 	}
 	updateMempoolMetrics(len(mp.verifiedTxes))
 	mp.lock.Unlock()
@@ -232,6 +250,18 @@ func (mp *Pool) Remove(hash util.Uint256) {
 // only the transactions for which it returns a true result. It's used to quickly
 // drop part of the mempool that is now invalid after the block acceptance.
 func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer) {
+	mp.RemoveStaleWithReason(func(tx *transaction.Transaction) RemovalReason {
+		if isOK(tx) {
+			return ReasonNone
+		}
+		return ReasonInvalid
+	}, feer)
+}
+
+// RemoveStaleWithReason is similar to RemoveStale, but the given function
+// returns the reason for transaction removal (which is passed to subscribers)
+// or ReasonNone for transactions that are to be kept.
+func (mp *Pool) RemoveStaleWithReason(check func(*transaction.Transaction) RemovalReason, feer Feer) {
 	mp.lock.Lock()
 	policyChanged := mp.loadPolicy(feer)
 	// We can reuse already allocated slice
@@ -239,10 +269,18 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 	newVerifiedTxes := mp.verifiedTxes[:0]
 	mp.fees = make(map[util.Uint160]utilityBalanceAndFees) // it'd be nice to reuse existing map, but we can't easily clear it
 	for _, itm := range mp.verifiedTxes {
-		if isOK(itm.txn) && mp.checkPolicy(itm.txn, policyChanged) && mp.tryAddSendersFee(itm.txn, feer, true) {
+		reason := check(itm.txn)
+		if reason == ReasonNone && !mp.checkPolicy(itm.txn, policyChanged) {
+			reason = ReasonPolicy
+		}
+		if reason == ReasonNone && !mp.tryAddSendersFee(itm.txn, feer, true) {
+			reason = ReasonInvalid
+		}
+		if reason == ReasonNone {
 			newVerifiedTxes = append(newVerifiedTxes, itm)
 		} else {
 			delete(mp.verifiedMap, itm.txn.Hash())
+			mp.emit(TransactionRemoved, itm.txn, reason)
 		}
 	}
 	mp.verifiedTxes = newVerifiedTxes
@@ -275,9 +313,35 @@ func New(capacity int) *Pool {
 		verifiedTxes: make([]*item, 0, capacity),
 		capacity:     capacity,
 		fees:         make(map[util.Uint160]utilityBalanceAndFees),
+		stopCh:       make(chan struct{}),
+		events:       make(chan struct{}, 1),
+		subCh:        make(chan chan<- Event),
+		unsubCh:      make(chan chan<- Event),
 	}
 }
 
+// Check checks whether the given transaction can be added to the Pool
+// returning the same errors Add would return, but it doesn't change the Pool.
+func (mp *Pool) Check(t *transaction.Transaction, fee Feer) error {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	if mp.containsKey(t.Hash()) {
+		return ErrDup
+	}
+	err := mp.checkTxConflicts(t, fee)
+	if err != nil {
+		return err
+	}
+	// Add inserts the new item before the first one it's more prioritized
+	// than, so it only fits into the full pool if it's more prioritized than
+	// the last one.
+	if len(mp.verifiedTxes) == mp.capacity &&
+		(&item{txn: t}).CompareTo(mp.verifiedTxes[len(mp.verifiedTxes)-1]) <= 0 {
+		return ErrOOM
+	}
+	return nil
+}
+
 // TryGetValue returns a transaction and its fee if it exists in the memory pool.
 func (mp *Pool) TryGetValue(hash util.Uint256) (*transaction.Transaction, bool) {
 	mp.lock.RLock()
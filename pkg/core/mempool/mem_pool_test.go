@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"errors"
 	"math/big"
 	"sort"
 	"testing"
@@ -293,3 +294,30 @@ func TestMempoolItemsOrder(t *testing.T) {
 	require.True(t, item3.CompareTo(item4) > 0)
 	require.True(t, item4.CompareTo(item3) < 0)
 }
+
+func TestCheck(t *testing.T) {
+	fs := &FeerStub{}
+	mp := New(2)
+	newTx := func(nonce uint32, acc util.Uint160, netFee int64) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		tx.SystemFee = 4000000
+		tx.NetworkFee = netFee
+		return tx
+	}
+	tx1 := newTx(1, util.Uint160{1, 2, 3}, 1)
+	require.NoError(t, mp.Check(tx1, fs))
+	require.Equal(t, 0, mp.Count())
+	require.NoError(t, mp.Add(tx1, fs))
+	require.True(t, errors.Is(mp.Check(tx1, fs), ErrDup))
+
+	require.NoError(t, mp.Add(newTx(2, util.Uint160{1, 2, 3}, 2), fs))
+	// Fees of pooled transactions are accounted for.
+	require.True(t, errors.Is(mp.Check(newTx(3, util.Uint160{1, 2, 3}, 3), fs), ErrConflict))
+
+	// The pool is full, so only more prioritized transactions fit.
+	require.True(t, errors.Is(mp.Check(newTx(4, util.Uint160{4, 5, 6}, 0), fs), ErrOOM))
+	require.NoError(t, mp.Check(newTx(5, util.Uint160{4, 5, 6}, 3), fs))
+	require.Equal(t, 2, mp.Count())
+}
@@ -0,0 +1,210 @@
+package mempool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+type (
+	// EventType is a mempool event type.
+	EventType byte
+
+	// RemovalReason describes why transaction was removed from the pool.
+	RemovalReason byte
+
+	// Event represents one of mempool events: transaction was added to or
+	// removed from the pool. Reason is only set for removals.
+	Event struct {
+		Type   EventType
+		Tx     *transaction.Transaction
+		Reason RemovalReason
+	}
+)
+
+const (
+	// TransactionAdded marks transaction addition mempool event.
+	TransactionAdded EventType = 0x01
+	// TransactionRemoved marks transaction removal mempool event.
+	TransactionRemoved EventType = 0x02
+	// EventsMissed is sent instead of events dropped because subscribers
+	// can't keep up with the pool, it has no transaction.
+	EventsMissed EventType = 0x03
+)
+
+// maxQueuedEvents is the maximum number of events waiting to be dispatched,
+// subsequent ones are dropped until the dispatcher takes them.
+const maxQueuedEvents = 16384
+
+const (
+	// ReasonNone is not a removal reason, it's used by stale transaction
+	// checks for transactions that are to be kept in the pool.
+	ReasonNone RemovalReason = iota
+	// ReasonIncluded is used for transactions included into a block.
+	ReasonIncluded
+	// ReasonExpired is used for transactions with ValidUntilBlock reached.
+	ReasonExpired
+	// ReasonEvicted is used for transactions evicted from the full pool by
+	// transactions with higher fees.
+	ReasonEvicted
+	// ReasonPolicy is used for transactions that don't fit the policy
+	// (fee per byte) anymore after its change.
+	ReasonPolicy
+	// ReasonInvalid is used for transactions that fail reverification or
+	// whose senders can't pay for them anymore.
+	ReasonInvalid
+	// ReasonRemoved is used for transactions removed explicitly via Remove.
+	ReasonRemoved
+)
+
+// String implements fmt.Stringer interface.
+func (r RemovalReason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonIncluded:
+		return "included"
+	case ReasonExpired:
+		return "expired"
+	case ReasonEvicted:
+		return "evicted"
+	case ReasonPolicy:
+		return "policy"
+	case ReasonInvalid:
+		return "invalid"
+	case ReasonRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("unknown (%d)", byte(r))
+	}
+}
+
+// RemovalReasonFromString converts input string into a RemovalReason.
+func RemovalReasonFromString(s string) (RemovalReason, error) {
+	for r := ReasonNone; r <= ReasonRemoved; r++ {
+		if r.String() == s {
+			return r, nil
+		}
+	}
+	return ReasonNone, errors.New("invalid removal reason")
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (r RemovalReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (r *RemovalReason) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	reason, err := RemovalReasonFromString(s)
+	if err != nil {
+		return err
+	}
+	*r = reason
+	return nil
+}
+
+// RunSubscriptions starts mempool events dispatcher, events are only
+// generated after it's started. The pool can't be subscribed to before this
+// call and it can't be restarted after StopSubscriptions.
+func (mp *Pool) RunSubscriptions() {
+	if !mp.subscriptionsOn.CAS(false, true) {
+		return
+	}
+	go mp.notificationDispatcher()
+}
+
+// StopSubscriptions stops mempool events dispatcher.
+func (mp *Pool) StopSubscriptions() {
+	if mp.subscriptionsOn.CAS(true, false) {
+		close(mp.stopCh)
+	}
+}
+
+// SubscribeForTransactions adds given channel to new mempool event broadcasting,
+// so when there is a new transaction added to the pool or an existing one
+// removed from it you'll receive it via this channel. Events are queued, so
+// a slow reader doesn't block the pool, but it delays events delivery for
+// other subscribers and if the queue is full events are dropped with
+// EventsMissed event sent instead of them.
+func (mp *Pool) SubscribeForTransactions(ch chan<- Event) {
+	select {
+	case mp.subCh <- ch:
+	case <-mp.stopCh:
+	}
+}
+
+// UnsubscribeFromTransactions unsubscribes given channel from new mempool
+// notifications, you can close it afterwards. Passing non-subscribed channel
+// is a no-op.
+func (mp *Pool) UnsubscribeFromTransactions(ch chan<- Event) {
+	select {
+	case mp.unsubCh <- ch:
+	case <-mp.stopCh:
+	}
+}
+
+// notificationDispatcher manages subscriptions to mempool events and
+// broadcasts them.
+func (mp *Pool) notificationDispatcher() {
+	var (
+		// It's just a set of subscribers, modelled as a map for ease
+		// of management.
+		txFeed = make(map[chan<- Event]bool)
+	)
+	for {
+		select {
+		case <-mp.stopCh:
+			return
+		case ch := <-mp.subCh:
+			txFeed[ch] = true
+		case ch := <-mp.unsubCh:
+			delete(txFeed, ch)
+		case <-mp.events:
+			mp.eventsLock.Lock()
+			events := mp.eventQueue
+			mp.eventQueue = nil
+			mp.eventsMissed = false
+			mp.eventsLock.Unlock()
+			for _, event := range events {
+				for ch := range txFeed {
+					select {
+					case ch <- event:
+					case <-mp.stopCh:
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// emit queues the event for the dispatcher if subscriptions are enabled. It's
+// called with the pool lock held to keep events ordered, but never blocks.
+// If the queue is full the event is dropped and EventsMissed is queued
+// instead (once until the dispatcher takes the queue).
+func (mp *Pool) emit(typ EventType, tx *transaction.Transaction, reason RemovalReason) {
+	if !mp.subscriptionsOn.Load() {
+		return
+	}
+	mp.eventsLock.Lock()
+	switch {
+	case mp.eventsMissed:
+	case len(mp.eventQueue) >= maxQueuedEvents:
+		mp.eventsMissed = true
+		mp.eventQueue = append(mp.eventQueue, Event{Type: EventsMissed})
+	default:
+		mp.eventQueue = append(mp.eventQueue, Event{Type: typ, Tx: tx, Reason: reason})
+	}
+	mp.eventsLock.Unlock()
+	select {
+	case mp.events <- struct{}{}:
+	default: // The dispatcher is to be woken up already.
+	}
+}
@@ -0,0 +1,176 @@
+package mempool
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptions(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		mp := New(2)
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		// Doesn't block without subscriptions running.
+		require.NoError(t, mp.Add(tx, &FeerStub{}))
+		mp.Remove(tx.Hash())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		fs := &FeerStub{}
+		mp := New(2)
+		mp.RunSubscriptions()
+		defer mp.StopSubscriptions()
+
+		subChan1 := make(chan Event, 8)
+		subChan2 := make(chan Event, 8)
+		mp.SubscribeForTransactions(subChan1)
+		mp.SubscribeForTransactions(subChan2)
+
+		txs := make([]*transaction.Transaction, 5)
+		for i := range txs {
+			txs[i] = transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+			txs[i].Nonce = uint32(i)
+			txs[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+			txs[i].NetworkFee = int64(i)
+		}
+		getEvent := func(t *testing.T, ch chan Event) Event {
+			select {
+			case e := <-ch:
+				return e
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for event")
+			}
+			return Event{}
+		}
+		checkEvent := func(t *testing.T, typ EventType, tx *transaction.Transaction, reason RemovalReason) {
+			for _, ch := range []chan Event{subChan1, subChan2} {
+				require.Equal(t, Event{Type: typ, Tx: tx, Reason: reason}, getEvent(t, ch))
+			}
+		}
+
+		require.NoError(t, mp.Add(txs[0], fs))
+		checkEvent(t, TransactionAdded, txs[0], ReasonNone)
+		require.NoError(t, mp.Add(txs[1], fs))
+		checkEvent(t, TransactionAdded, txs[1], ReasonNone)
+
+		// The pool is full, so the cheapest transaction is evicted.
+		require.NoError(t, mp.Add(txs[2], fs))
+		checkEvent(t, TransactionRemoved, txs[0], ReasonEvicted)
+		checkEvent(t, TransactionAdded, txs[2], ReasonNone)
+
+		mp.Remove(txs[1].Hash())
+		checkEvent(t, TransactionRemoved, txs[1], ReasonRemoved)
+
+		mp.UnsubscribeFromTransactions(subChan2)
+		require.NoError(t, mp.Add(txs[3], fs))
+		require.Equal(t, Event{Type: TransactionAdded, Tx: txs[3]}, getEvent(t, subChan1))
+
+		mp.RemoveStaleWithReason(func(tx *transaction.Transaction) RemovalReason {
+			if tx == txs[2] {
+				return ReasonIncluded
+			}
+			return ReasonNone
+		}, fs)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2], Reason: ReasonIncluded}, getEvent(t, subChan1))
+
+		// Fee per byte policy change drops transaction with low fee.
+		mp.RemoveStale(func(*transaction.Transaction) bool { return true }, &FeerStub{feePerByte: 1})
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[3], Reason: ReasonPolicy}, getEvent(t, subChan1))
+
+		mp.UnsubscribeFromTransactions(subChan1)
+		require.NoError(t, mp.Add(txs[4], fs))
+		require.Equal(t, 0, len(subChan1))
+		require.Equal(t, 0, len(subChan2))
+	})
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		mp := New(10)
+		mp.RunSubscriptions()
+		defer mp.StopSubscriptions()
+
+		ch := make(chan Event)
+		mp.SubscribeForTransactions(ch)
+		txs := make([]*transaction.Transaction, 3)
+		for i := range txs {
+			txs[i] = transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+			txs[i].Nonce = uint32(i)
+			txs[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+			// Nobody reads the channel, but pool changes don't block.
+			require.NoError(t, mp.Add(txs[i], &FeerStub{}))
+		}
+		mp.Remove(txs[0].Hash())
+		for i := range txs {
+			require.Equal(t, Event{Type: TransactionAdded, Tx: txs[i]}, <-ch)
+		}
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0], Reason: ReasonRemoved}, <-ch)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		mp := New(2)
+		mp.RunSubscriptions()
+		defer mp.StopSubscriptions()
+
+		ch := make(chan Event)
+		mp.SubscribeForTransactions(ch)
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		// The dispatcher takes the first event and blocks sending it.
+		mp.emit(TransactionAdded, tx, ReasonNone)
+		require.Eventually(t, func() bool {
+			mp.eventsLock.Lock()
+			defer mp.eventsLock.Unlock()
+			return len(mp.eventQueue) == 0
+		}, time.Second, time.Millisecond)
+		for i := 0; i < maxQueuedEvents+10; i++ {
+			mp.emit(TransactionRemoved, tx, ReasonRemoved)
+		}
+		mp.eventsLock.Lock()
+		require.Equal(t, maxQueuedEvents+1, len(mp.eventQueue))
+		mp.eventsLock.Unlock()
+
+		require.Equal(t, Event{Type: TransactionAdded, Tx: tx}, <-ch)
+		for i := 0; i < maxQueuedEvents; i++ {
+			require.Equal(t, Event{Type: TransactionRemoved, Tx: tx, Reason: ReasonRemoved}, <-ch)
+		}
+		require.Equal(t, Event{Type: EventsMissed}, <-ch)
+		// Events are queued again after that.
+		mp.emit(TransactionAdded, tx, ReasonNone)
+		require.Equal(t, Event{Type: TransactionAdded, Tx: tx}, <-ch)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		mp := New(2)
+		mp.RunSubscriptions()
+		mp.StopSubscriptions()
+		// Neither of these blocks after the dispatcher is stopped.
+		ch := make(chan Event)
+		mp.SubscribeForTransactions(ch)
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(tx, &FeerStub{}))
+		mp.UnsubscribeFromTransactions(ch)
+	})
+}
+
+func TestRemovalReasonJSON(t *testing.T) {
+	for r := ReasonNone; r <= ReasonRemoved; r++ {
+		data, err := json.Marshal(r)
+		require.NoError(t, err)
+		var actual RemovalReason
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, r, actual)
+	}
+	data, err := json.Marshal(ReasonExpired)
+	require.NoError(t, err)
+	require.Equal(t, `"expired"`, string(data))
+
+	var r RemovalReason
+	require.Error(t, json.Unmarshal([]byte(`"stolen"`), &r))
+	require.Error(t, json.Unmarshal([]byte(`1`), &r))
+}
@@ -0,0 +1,92 @@
+package mpt
+
+import (
+	"bytes"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+)
+
+// Find returns key-value pairs with the given prefix stored in t in ascending
+// key order. If from is not nil, only keys strictly greater than from are
+// returned. At most max pairs are returned, max <= 0 means no limit.
+func (t *Trie) Find(prefix, from []byte, max int) ([]storage.KeyValue, error) {
+	f := &finder{
+		trie:   t,
+		prefix: toNibbles(prefix),
+		max:    max,
+	}
+	if from != nil {
+		f.from = toNibbles(from)
+	}
+	if _, err := f.walk(t.root, []byte{}); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// finder holds the state of a single Find traversal.
+type finder struct {
+	trie   *Trie
+	prefix []byte
+	from   []byte
+	max    int
+	result []storage.KeyValue
+}
+
+// walk traverses subtrie rooting in curr with the given path (in nibbles)
+// in ascending order. It returns true if the traversal should be stopped.
+func (f *finder) walk(curr Node, path []byte) (bool, error) {
+	if !f.mayContain(path) {
+		return false, nil
+	}
+	switch n := curr.(type) {
+	case *LeafNode:
+		if len(path)%2 != 0 || !bytes.HasPrefix(path, f.prefix) ||
+			(f.from != nil && bytes.Compare(path, f.from) <= 0) {
+			return false, nil
+		}
+		f.result = append(f.result, storage.KeyValue{
+			Key:   fromNibbles(path),
+			Value: copySlice(n.value),
+		})
+		return f.max > 0 && len(f.result) >= f.max, nil
+	case *BranchNode:
+		// Value stored in the branch itself has the shortest key.
+		stop, err := f.walk(n.Children[lastChild], path)
+		if stop || err != nil {
+			return stop, err
+		}
+		for i := 0; i < lastChild; i++ {
+			stop, err := f.walk(n.Children[i], append(copySlice(path), byte(i)))
+			if stop || err != nil {
+				return stop, err
+			}
+		}
+		return false, nil
+	case *ExtensionNode:
+		return f.walk(n.next, append(copySlice(path), n.key...))
+	case *HashNode:
+		if n.IsEmpty() {
+			return false, nil
+		}
+		r, err := f.trie.getFromStore(n.hash)
+		if err != nil {
+			return false, err
+		}
+		return f.walk(r, path)
+	default:
+		panic("invalid MPT node type")
+	}
+}
+
+// mayContain checks whether subtrie with the given path can contain keys
+// matching prefix and following from.
+func (f *finder) mayContain(path []byte) bool {
+	if !bytes.HasPrefix(path, f.prefix) && !bytes.HasPrefix(f.prefix, path) {
+		return false
+	}
+	if f.from == nil || bytes.HasPrefix(f.from, path) {
+		return true
+	}
+	return bytes.Compare(path, f.from) > 0
+}
@@ -0,0 +1,64 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func newFindTrie(t *testing.T) *Trie {
+	tr := NewTrie(nil, newTestStore())
+	for _, k := range []string{"a", "ab", "abc", "abd", "b", "ba", "c"} {
+		require.NoError(t, tr.Put([]byte(k), []byte("v"+k)))
+	}
+	tr.Flush()
+	// Check that hash nodes are resolved too.
+	return NewTrie(NewHashNode(tr.StateRoot()), tr.Store)
+}
+
+func TestTrie_Find(t *testing.T) {
+	tr := newFindTrie(t)
+	check := func(t *testing.T, prefix, from []byte, max int, keys ...string) {
+		res, err := tr.Find(prefix, from, max)
+		require.NoError(t, err)
+		expected := make([]storage.KeyValue, len(keys))
+		for i := range keys {
+			expected[i] = storage.KeyValue{Key: []byte(keys[i]), Value: []byte("v" + keys[i])}
+		}
+		if len(keys) == 0 {
+			expected = nil
+		}
+		require.Equal(t, expected, res)
+	}
+
+	t.Run("All", func(t *testing.T) {
+		check(t, nil, nil, 0, "a", "ab", "abc", "abd", "b", "ba", "c")
+	})
+	t.Run("Prefix", func(t *testing.T) {
+		check(t, []byte("ab"), nil, 0, "ab", "abc", "abd")
+		check(t, []byte("b"), nil, 0, "b", "ba")
+		check(t, []byte("d"), nil, 0)
+	})
+	t.Run("From", func(t *testing.T) {
+		check(t, nil, []byte("ab"), 0, "abc", "abd", "b", "ba", "c")
+		check(t, nil, []byte("abz"), 0, "b", "ba", "c")
+		check(t, []byte("a"), []byte("abc"), 0, "abd")
+		check(t, nil, []byte("c"), 0)
+	})
+	t.Run("Max", func(t *testing.T) {
+		check(t, nil, nil, 2, "a", "ab")
+		check(t, nil, []byte("abd"), 2, "b", "ba")
+		check(t, []byte("ab"), []byte("ab"), 1, "abc")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		res, err := NewTrie(nil, newTestStore()).Find(nil, nil, 0)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+	t.Run("MissingHashNode", func(t *testing.T) {
+		tr := NewTrie(NewHashNode(tr.StateRoot()), newTestStore())
+		_, err := tr.Find(nil, nil, 0)
+		require.Error(t, err)
+	})
+}
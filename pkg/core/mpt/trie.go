@@ -63,9 +63,11 @@ func (t *Trie) getWithPath(curr Node, path []byte) (Node, []byte, error) {
 		return n, bs, nil
 	case *HashNode:
 		if !n.IsEmpty() {
-			if r, err := t.getFromStore(n.hash); err == nil {
-				return t.getWithPath(r, path)
+			r, err := t.getFromStore(n.hash)
+			if err != nil {
+				return nil, nil, err
 			}
+			return t.getWithPath(r, path)
 		}
 	case *ExtensionNode:
 		if bytes.HasPrefix(path, n.key) {
@@ -1,6 +1,7 @@
 package mpt
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
@@ -214,6 +215,12 @@ func TestTrie_Get(t *testing.T) {
 		single.testHas(t, []byte{0xAC, 0x99}, []byte{0x22, 0x22})
 		single.testHas(t, []byte{0xAC, 0xAE}, []byte("hello"))
 	})
+	t.Run("MissingNode", func(t *testing.T) {
+		tr := NewTrie(NewHashNode(random.Uint256()), newTestStore())
+		_, err := tr.Get([]byte{0xAC})
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrNotFound))
+	})
 }
 
 func TestTrie_Flush(t *testing.T) {
@@ -0,0 +1,25 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NativeContract holds information about native contract.
+type NativeContract struct {
+	ID       int32             `json:"id"`
+	Hash     util.Uint160      `json:"hash"`
+	Name     string            `json:"name"`
+	Script   []byte            `json:"script"`
+	Manifest manifest.Manifest `json:"manifest"`
+	Methods  []NativeMethod    `json:"methods"`
+}
+
+// NativeMethod describes native contract method price and call flags
+// required to invoke it.
+type NativeMethod struct {
+	Name          string                 `json:"name"`
+	Price         int64                  `json:"price"`
+	RequiredFlags smartcontract.CallFlag `json:"requiredflags"`
+}
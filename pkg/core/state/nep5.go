@@ -1,6 +1,8 @@
 package state
 
 import (
+	"encoding/binary"
+	"errors"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
@@ -24,6 +26,18 @@ type NEP5TransferLog struct {
 	size int
 }
 
+const (
+	// nep5TransferTimestampOffset is the offset of Timestamp field in the
+	// serialized NEP5Transfer.
+	nep5TransferTimestampOffset = 4 + util.Uint256Size + util.Uint160Size*2 + 4
+	// nep5TransferAmountOffset is the offset of the amount length in the
+	// serialized NEP5Transfer, the amount itself follows it.
+	nep5TransferAmountOffset = nep5TransferTimestampOffset + 8
+)
+
+// ErrInvalidTransferLog is returned when transfer log data is malformed.
+var ErrInvalidTransferLog = errors.New("invalid NEP5 transfer log")
+
 // NEP5Transfer represents a single NEP5 Transfer event.
 type NEP5Transfer struct {
 	// Asset is a NEP5 contract ID.
@@ -113,6 +127,58 @@ func (lg *NEP5TransferLog) ForEach(f func(*NEP5Transfer) error) error {
 	return nil
 }
 
+// FirstTimestamp returns the timestamp of the first transfer in the log
+// without decoding it. As transfers are appended to the log in chronological
+// order it's the lowest timestamp of the log. The second result is false for
+// an empty log.
+func (lg *NEP5TransferLog) FirstTimestamp() (uint64, bool) {
+	if lg == nil || len(lg.Raw) < nep5TransferAmountOffset {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(lg.Raw[nep5TransferTimestampOffset:]), true
+}
+
+// Seek returns offsets of transfers with timestamps within [start, end]
+// range in the order they were appended to the log. Only timestamps and
+// amount lengths are read, so it's much cheaper than decoding every transfer,
+// use Get to decode the ones needed.
+func (lg *NEP5TransferLog) Seek(start, end uint64) ([]int, error) {
+	if lg == nil {
+		return nil, nil
+	}
+	var offsets []int
+	for i := 0; i < len(lg.Raw); {
+		if len(lg.Raw)-i < nep5TransferAmountOffset+8 {
+			return nil, ErrInvalidTransferLog
+		}
+		ts := binary.LittleEndian.Uint64(lg.Raw[i+nep5TransferTimestampOffset:])
+		amountLen := binary.LittleEndian.Uint64(lg.Raw[i+nep5TransferAmountOffset:])
+		if amountLen > uint64(len(lg.Raw)-i-nep5TransferAmountOffset-8) {
+			return nil, ErrInvalidTransferLog
+		}
+		if ts >= start && ts <= end {
+			offsets = append(offsets, i)
+		}
+		i += nep5TransferAmountOffset + 8 + int(amountLen)
+	}
+	return offsets, nil
+}
+
+// Get decodes the transfer located at the given offset of the log (as
+// returned by Seek).
+func (lg *NEP5TransferLog) Get(offset int) (*NEP5Transfer, error) {
+	if lg == nil || offset < 0 || offset >= len(lg.Raw) {
+		return nil, ErrInvalidTransferLog
+	}
+	tr := new(NEP5Transfer)
+	r := io.NewBinReaderFromBuf(lg.Raw[offset:])
+	tr.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return tr, nil
+}
+
 // Size returns an amount of transfer written in log.
 func (lg *NEP5TransferLog) Size() int {
 	return lg.size
@@ -39,6 +39,49 @@ func TestNEP5TransferLog_Append(t *testing.T) {
 
 }
 
+func TestNEP5TransferLog_Seek(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	lg := new(NEP5TransferLog)
+	_, ok := lg.FirstTimestamp()
+	require.False(t, ok)
+	offsets, err := lg.Seek(0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(offsets))
+
+	expected := make([]*NEP5Transfer, 5)
+	for i := range expected {
+		expected[i] = randomTransfer(r)
+		expected[i].Timestamp = uint64(10 * (i + 1))
+		require.NoError(t, lg.Append(expected[i]))
+	}
+
+	ts, ok := lg.FirstTimestamp()
+	require.True(t, ok)
+	require.Equal(t, uint64(10), ts)
+
+	offsets, err = lg.Seek(20, 40)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(offsets))
+	for i, off := range offsets {
+		tr, err := lg.Get(off)
+		require.NoError(t, err)
+		require.Equal(t, expected[i+1], tr)
+	}
+
+	offsets, err = lg.Seek(51, 100)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(offsets))
+
+	_, err = lg.Get(len(lg.Raw))
+	require.Error(t, err)
+
+	t.Run("truncated", func(t *testing.T) {
+		bad := &NEP5TransferLog{Raw: lg.Raw[:len(lg.Raw)-1]}
+		_, err := bad.Seek(0, 100)
+		require.Error(t, err)
+	})
+}
+
 func TestNEP5Tracker_EncodeBinary(t *testing.T) {
 	expected := &NEP5Tracker{
 		Balance:          *big.NewInt(int64(rand.Uint64())),
@@ -0,0 +1,55 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// ExecutionTrace is a detailed record of script execution collected for
+// test invocations. It contains contract call tree, all storage changes made
+// via System.Storage.* syscalls, notifications and log messages emitted and
+// the location of the exception that led to FAULT (if any).
+type ExecutionTrace struct {
+	Calls         *CallFrame
+	Storage       []StorageChange
+	Notifications []NotificationEvent
+	Logs          []LogEvent
+	Exception     *ExceptionLocation
+}
+
+// CallFrame is a node of the contract call tree. The root frame is the entry
+// script, all other frames are System.Contract.Call invocations made from
+// their parent frame. GasConsumed includes GAS spent by nested calls.
+type CallFrame struct {
+	ScriptHash  util.Uint160
+	Method      string
+	GasConsumed int64
+	Calls       []*CallFrame
+}
+
+// StorageChange describes single storage item modification done by the
+// contract with the given ScriptHash. OldValue is nil if there was no such
+// item before the change, NewValue is nil for deletions.
+type StorageChange struct {
+	ScriptHash util.Uint160
+	ID         int32
+	Key        []byte
+	OldValue   []byte
+	NewValue   []byte
+	Deleted    bool
+}
+
+// LogEvent is a message logged via System.Runtime.Log by the contract with
+// the given ScriptHash.
+type LogEvent struct {
+	ScriptHash util.Uint160
+	Message    string
+}
+
+// ExceptionLocation points to the instruction that caused VM to FAULT.
+type ExceptionLocation struct {
+	ScriptHash util.Uint160
+	IP         int
+	Opcode     opcode.Opcode
+	Message    string
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -92,9 +93,15 @@ func (chain testChain) GetHeader(hash util.Uint256) (*block.Header, error) {
 func (chain testChain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 	panic("TODO")
 }
+func (chain testChain) SeekNEP5Transfers(util.Uint160, uint64, uint64, func(*state.NEP5Transfer) (bool, error)) error {
+	panic("TODO")
+}
 func (chain testChain) ForEachNEP5Transfer(util.Uint160, func(*state.NEP5Transfer) error) error {
 	panic("TODO")
 }
+func (chain testChain) GetNatives() []state.NativeContract {
+	panic("TODO")
+}
 func (chain testChain) GetNEP5Balances(util.Uint160) *state.NEP5Balances {
 	panic("TODO")
 }
@@ -110,6 +117,18 @@ func (chain testChain) GetStandByValidators() keys.PublicKeys {
 func (chain testChain) GetEnrollments() ([]state.Validator, error) {
 	panic("TODO")
 }
+func (chain testChain) GetCommittee() (keys.PublicKeys, error) {
+	panic("TODO")
+}
+func (chain testChain) GetStateProof(util.Uint256, []byte) ([][]byte, error) {
+	panic("TODO")
+}
+func (chain testChain) FindStates(util.Uint256, []byte, []byte, int) ([]storage.KeyValue, error) {
+	panic("TODO")
+}
+func (chain testChain) StateHeight() uint32 {
+	panic("TODO")
+}
 func (chain testChain) GetStateRoot(height uint32) (*state.MPTRootState, error) {
 	panic("TODO")
 }
@@ -119,6 +138,15 @@ func (chain testChain) GetStorageItem(id int32, key []byte) *state.StorageItem {
 func (chain testChain) GetTestVM(tx *transaction.Transaction) *vm.VM {
 	panic("TODO")
 }
+func (chain testChain) GetTestHistoricVM(*transaction.Transaction, util.Uint256) *vm.VM {
+	panic("TODO")
+}
+func (chain testChain) TraceTestScript(*transaction.Transaction, *util.Uint256, []byte, int64) (*vm.VM, *state.ExecutionTrace) {
+	panic("TODO")
+}
+func (chain testChain) GetTestVerificationVM(*transaction.Transaction, util.Uint160, *transaction.Witness) (*vm.VM, error) {
+	panic("TODO")
+}
 func (chain testChain) GetStorageItems(id int32) (map[string]*state.StorageItem, error) {
 	panic("TODO")
 }
@@ -170,6 +198,9 @@ func (chain testChain) SubscribeForTransactions(ch chan<- *transaction.Transacti
 func (chain testChain) VerifyTx(*transaction.Transaction) error {
 	panic("TODO")
 }
+func (chain testChain) VerifyTxAgainstPool(*transaction.Transaction) error {
+	panic("TODO")
+}
 func (testChain) VerifyWitness(util.Uint160, crypto.Verifiable, *transaction.Witness, int64) error {
 	panic("TODO")
 }
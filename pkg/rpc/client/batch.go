@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+)
+
+// Batch is a set of requests that are sent to the server at once as a single
+// JSON-RPC 2.0 batch. It's created with Client.NewBatch, filled with Add and
+// executed with Send.
+type Batch struct {
+	c       *Client
+	reqs    []*request.Raw
+	results []interface{}
+}
+
+// NewBatch creates a new empty request batch for this client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// Add adds a call of the given method with the given parameters to the batch,
+// result of this call is unmarshaled into v when the batch is sent. It
+// returns an index of the request in the batch.
+func (b *Batch) Add(method string, p request.RawParams, v interface{}) int {
+	b.reqs = append(b.reqs, &request.Raw{
+		JSONRPC:   request.JSONRPCVersion,
+		Method:    method,
+		RawParams: p.Values,
+		ID:        len(b.reqs) + 1,
+	})
+	b.results = append(b.results, v)
+	return len(b.reqs) - 1
+}
+
+// Len returns the number of requests in the batch.
+func (b *Batch) Len() int {
+	return len(b.reqs)
+}
+
+// Send sends all requests of the batch to the server and unmarshals their
+// results into the values given to Add. Returned error is only non-nil if the
+// batch as a whole has failed, errors of particular requests are returned as
+// a slice with the same order as requests were added (nil for successful
+// ones).
+func (b *Batch) Send() ([]error, error) {
+	if len(b.reqs) == 0 {
+		return nil, errors.New("empty batch")
+	}
+	resps, err := b.c.batchF(b.reqs)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		errs = make([]error, len(b.reqs))
+		seen = make([]bool, len(b.reqs))
+	)
+	// Responses can come in any order, so they're matched by ID.
+	for _, resp := range resps {
+		id, err := strconv.Atoi(string(resp.ID))
+		if err != nil || id < 1 || id > len(b.reqs) || seen[id-1] {
+			return nil, fmt.Errorf("unexpected response ID %s", string(resp.ID))
+		}
+		seen[id-1] = true
+		if resp.Error != nil {
+			errs[id-1] = resp.Error
+		} else if resp.Result == nil {
+			errs[id-1] = errors.New("no result returned")
+		} else {
+			errs[id-1] = json.Unmarshal(resp.Result, b.results[id-1])
+		}
+	}
+	for i := range seen {
+		if !seen[i] {
+			errs[i] = errors.New("no response returned")
+		}
+	}
+	return errs, nil
+}
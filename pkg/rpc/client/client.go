@@ -3,15 +3,18 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 )
@@ -32,6 +35,7 @@ type Client struct {
 	ctx      context.Context
 	opts     Options
 	requestF func(*request.Raw) (*response.Raw, error)
+	batchF   func([]*request.Raw) (response.Batch, error)
 	cache    cache
 }
 
@@ -47,11 +51,23 @@ type Options struct {
 	DialTimeout    time.Duration
 	RequestTimeout time.Duration
 	Network        netmode.Magic
+
+	// User and Password are used for HTTP Basic authentication if User is
+	// not empty.
+	User     string
+	Password string
+	// BearerToken (either a static token or a JWT) is sent in Authorization
+	// header if it's not empty, it's ignored if User is specified.
+	BearerToken string
+	// Reconnect enables automatic reconnection for WSClient, it's not used
+	// by Client.
+	Reconnect *ReconnectOptions
 }
 
 // cache stores cache values for the RPC client methods
 type cache struct {
 	calculateValidUntilBlock calculateValidUntilBlockCache
+	natives                  *nativesCache
 }
 
 // calculateValidUntilBlockCache stores cached number of validators and
@@ -61,6 +77,13 @@ type calculateValidUntilBlockCache struct {
 	expiresAt       uint32
 }
 
+// nativesCache stores native contracts, they're requested once and never
+// expire as they only change with node upgrades.
+type nativesCache struct {
+	lock      sync.Mutex
+	contracts []state.NativeContract
+}
+
 // New returns a new Client ready to use.
 func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
 	url, err := url.Parse(endpoint)
@@ -95,7 +118,9 @@ func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
 		endpoint: url,
 	}
 	cl.opts = opts
+	cl.cache.natives = new(nativesCache)
 	cl.requestF = cl.makeHTTPRequest
+	cl.batchF = cl.makeHTTPBatchRequest
 	return cl, nil
 }
 
@@ -120,28 +145,62 @@ func (c *Client) performRequest(method string, p request.RawParams, v interface{
 }
 
 func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
-	var (
-		buf = new(bytes.Buffer)
-		raw = new(response.Raw)
-	)
+	var raw = new(response.Raw)
+
+	if err := c.doHTTPRequest(r, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (c *Client) makeHTTPBatchRequest(rs []*request.Raw) (response.Batch, error) {
+	var data json.RawMessage
 
-	if err := json.NewEncoder(buf).Encode(r); err != nil {
+	if err := c.doHTTPRequest(rs, &data); err != nil {
 		return nil, err
 	}
+	// Server returns a single response if the batch can't be processed at
+	// all (it's too big, for example).
+	if len(data) != 0 && data[0] != '[' {
+		var raw = new(response.Raw)
+		if err := json.Unmarshal(data, raw); err != nil {
+			return nil, fmt.Errorf("JSON decoding: %w", err)
+		}
+		if raw.Error != nil {
+			return nil, raw.Error
+		}
+		return nil, errors.New("single response returned for batch request")
+	}
+	var resps response.Batch
+	if err := json.Unmarshal(data, &resps); err != nil {
+		return nil, fmt.Errorf("JSON decoding: %w", err)
+	}
+	return resps, nil
+}
+
+// doHTTPRequest sends JSON-encoded in to the server and decodes the response
+// into out.
+func (c *Client) doHTTPRequest(in interface{}, out interface{}) error {
+	var buf = new(bytes.Buffer)
+
+	if err := json.NewEncoder(buf).Encode(in); err != nil {
+		return err
+	}
 
 	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	req.Header = c.opts.authHeader()
 	resp, err := c.cli.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	// The node might send us proper JSON anyway, so look there first and if
 	// it parses, then it has more relevant data than HTTP error code.
-	err = json.NewDecoder(resp.Body).Decode(raw)
+	err = json.NewDecoder(resp.Body).Decode(out)
 	if err != nil {
 		if resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -149,10 +208,20 @@ func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
 			err = fmt.Errorf("JSON decoding: %w", err)
 		}
 	}
-	if err != nil {
-		return nil, err
+	return err
+}
+
+// authHeader returns HTTP header with credentials specified in the options.
+func (o *Options) authHeader() http.Header {
+	var header = make(http.Header)
+
+	if o.User != "" {
+		creds := base64.StdEncoding.EncodeToString([]byte(o.User + ":" + o.Password))
+		header.Set("Authorization", "Basic "+creds)
+	} else if o.BearerToken != "" {
+		header.Set("Authorization", "Bearer "+o.BearerToken)
 	}
-	return raw, nil
+	return header
 }
 
 // Ping attempts to create a connection to the endpoint.
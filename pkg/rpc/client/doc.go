@@ -11,6 +11,14 @@ Some of the methods also allow to pass a verbose bool. This will
 return a more pretty printed response from the server instead of
 a raw hex string.
 
+Pool
+
+Pool provides the same methods working with several nodes, every request is
+routed to the healthy node with the highest block count (nodes lagging too
+much are ejected until they catch up), while sendrawtransaction is sent to all
+of them. Network failures are retried according to PoolOptions, unhealthy
+nodes are used when there are no healthy ones.
+
 TODO:
 	Add missing methods to client.
 	Allow client to connect using client cert.
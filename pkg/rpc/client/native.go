@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// GetNativeContracts returns the list of native contracts deployed on the
+// node's network with their hashes, manifests and method prices.
+func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]state.NativeContract)
+	)
+	if err := c.performRequest("getnativecontracts", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetNativeContract returns native contract by its name (like "NEO", "GAS"
+// or "Policy"). Native contracts are requested from the node once and then
+// cached by the client.
+func (c *Client) GetNativeContract(name string) (*state.NativeContract, error) {
+	natives := c.cache.natives
+	natives.lock.Lock()
+	defer natives.lock.Unlock()
+	if natives.contracts == nil {
+		contracts, err := c.GetNativeContracts()
+		if err != nil {
+			return nil, err
+		}
+		natives.contracts = contracts
+	}
+	for i := range natives.contracts {
+		if natives.contracts[i].Name == name {
+			nc := natives.contracts[i]
+			return &nc, nil
+		}
+	}
+	return nil, fmt.Errorf("native contract %s not found", name)
+}
+
+// GetNativeContractHash returns native contract hash by its name using
+// native contracts cache (see GetNativeContract).
+func (c *Client) GetNativeContractHash(name string) (util.Uint160, error) {
+	nc, err := c.GetNativeContract(name)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return nc.Hash, nil
+}
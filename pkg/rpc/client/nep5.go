@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -19,7 +20,7 @@ import (
 type TransferTarget struct {
 	Token   util.Uint160
 	Address util.Uint160
-	Amount  int64
+	Amount  *big.Int
 }
 
 var (
@@ -66,30 +67,30 @@ func (c *Client) NEP5Symbol(tokenHash util.Uint160) (string, error) {
 }
 
 // NEP5TotalSupply invokes `totalSupply` NEP5 method on a specified contract.
-func (c *Client) NEP5TotalSupply(tokenHash util.Uint160) (int64, error) {
+func (c *Client) NEP5TotalSupply(tokenHash util.Uint160) (*big.Int, error) {
 	result, err := c.InvokeFunction(tokenHash, "totalSupply", []smartcontract.Parameter{}, nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	} else if result.State != "HALT" || len(result.Stack) == 0 {
-		return 0, errors.New("invalid VM state")
+		return nil, errors.New("invalid VM state")
 	}
 
-	return topIntFromStack(result.Stack)
+	return topBigIntFromStack(result.Stack)
 }
 
 // NEP5BalanceOf invokes `balanceOf` NEP5 method on a specified contract.
-func (c *Client) NEP5BalanceOf(tokenHash, acc util.Uint160) (int64, error) {
+func (c *Client) NEP5BalanceOf(tokenHash, acc util.Uint160) (*big.Int, error) {
 	result, err := c.InvokeFunction(tokenHash, "balanceOf", []smartcontract.Parameter{{
 		Type:  smartcontract.Hash160Type,
 		Value: acc,
 	}}, nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	} else if result.State != "HALT" || len(result.Stack) == 0 {
-		return 0, errors.New("invalid VM state")
+		return nil, errors.New("invalid VM state")
 	}
 
-	return topIntFromStack(result.Stack)
+	return topBigIntFromStack(result.Stack)
 }
 
 // NEP5TokenInfo returns full NEP5 token info.
@@ -113,7 +114,7 @@ func (c *Client) NEP5TokenInfo(tokenHash util.Uint160) (*wallet.Token, error) {
 // method of a given contract (token) to move specified amount of NEP5 assets
 // (in FixedN format using contract's number of decimals) to given account and
 // returns it. The returned transaction is not signed.
-func (c *Client) CreateNEP5TransferTx(acc *wallet.Account, to util.Uint160, token util.Uint160, amount int64, gas int64) (*transaction.Transaction, error) {
+func (c *Client) CreateNEP5TransferTx(acc *wallet.Account, to util.Uint160, token util.Uint160, amount *big.Int, gas int64) (*transaction.Transaction, error) {
 	return c.CreateNEP5MultiTransferTx(acc, gas, TransferTarget{
 		Token:   token,
 		Address: to,
@@ -175,7 +176,7 @@ func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee,
 // on a given token to move specified amount of NEP5 assets (in FixedN format
 // using contract's number of decimals) to given account and sends it to the
 // network returning just a hash of it.
-func (c *Client) TransferNEP5(acc *wallet.Account, to util.Uint160, token util.Uint160, amount int64, gas int64) (util.Uint256, error) {
+func (c *Client) TransferNEP5(acc *wallet.Account, to util.Uint160, token util.Uint160, amount *big.Int, gas int64) (util.Uint256, error) {
 	tx, err := c.CreateNEP5TransferTx(acc, to, token, amount, gas)
 	if err != nil {
 		return util.Uint256{}, err
@@ -203,14 +204,18 @@ func (c *Client) MultiTransferNEP5(acc *wallet.Account, gas int64, recipients ..
 }
 
 func topIntFromStack(st []stackitem.Item) (int64, error) {
-	index := len(st) - 1 // top stack element is last in the array
-	bi, err := st[index].TryInteger()
+	bi, err := topBigIntFromStack(st)
 	if err != nil {
 		return 0, err
 	}
 	return bi.Int64(), nil
 }
 
+func topBigIntFromStack(st []stackitem.Item) (*big.Int, error) {
+	index := len(st) - 1 // top stack element is last in the array
+	return st[index].TryInteger()
+}
+
 func topStringFromStack(st []stackitem.Item) (string, error) {
 	index := len(st) - 1 // top stack element is last in the array
 	bs, err := st[index].TryBytes()
@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+)
+
+const (
+	defaultHealthCheckInterval = 5 * time.Second
+	defaultRetryDelay          = 100 * time.Millisecond
+	defaultMaxRetryDelay       = 5 * time.Second
+)
+
+// Pool is a Client working with several RPC nodes. It checks nodes' health
+// periodically with getblockcount requests and routes every call to the
+// healthy node with the highest block count, except for sendrawtransaction
+// that is sent to all nodes of the pool. Failed requests are retried on other
+// nodes according to the PoolOptions, unhealthy nodes are used if there are
+// no healthy ones left.
+type Pool struct {
+	Client
+	opts PoolOptions
+
+	lock  sync.RWMutex
+	nodes []*poolNode
+
+	shutdown chan struct{}
+	done     chan struct{}
+}
+
+// PoolOptions defines options for the Pool. Options are used for clients
+// of every node in the pool, all other values are optional.
+type PoolOptions struct {
+	Options
+	// HealthCheckInterval is the interval between node health checks, 5
+	// seconds by default.
+	HealthCheckInterval time.Duration
+	// MaxLag is the number of blocks a node can lag behind the highest node
+	// of the pool. Nodes lagging more than that are ejected from the pool
+	// until they catch up.
+	MaxLag uint32
+	// Retries is the number of times a failed request is repeated (on
+	// another node if there is any), zero means no retries. Only
+	// network-level failures are retried, error responses are returned as
+	// is.
+	Retries int
+	// RetryDelay is the delay before the first retry (100ms by default), it
+	// doubles with each subsequent retry up to MaxRetryDelay (5 seconds by
+	// default).
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// poolNode is a single node of the Pool along with its last known state.
+type poolNode struct {
+	client *Client
+	// height is the block count returned by the last successful health
+	// check.
+	height  uint32
+	healthy bool
+}
+
+// NewPool returns a new Pool for the given endpoints ready to use. It checks
+// nodes' health before returning, but doesn't fail if there are no healthy
+// nodes, they can become available later.
+func NewPool(ctx context.Context, endpoints []string, opts PoolOptions) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints given")
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultRetryDelay
+	}
+	if opts.MaxRetryDelay <= 0 {
+		opts.MaxRetryDelay = defaultMaxRetryDelay
+	}
+
+	nodes := make([]*poolNode, len(endpoints))
+	for i := range endpoints {
+		cl, err := New(ctx, endpoints[i], opts.Options)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = &poolNode{client: cl}
+	}
+	// This one is only needed for its methods and cache, requests are
+	// routed via nodes' clients.
+	cl, err := New(ctx, endpoints[0], opts.Options)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pool{
+		Client:   *cl,
+		opts:     opts,
+		nodes:    nodes,
+		shutdown: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	p.requestF = p.makePoolRequest
+	p.batchF = p.makePoolBatchRequest
+	p.checkHealth()
+	go p.healthChecker()
+	return p, nil
+}
+
+// Close stops health checks of the pool.
+func (p *Pool) Close() {
+	close(p.shutdown)
+	<-p.done
+}
+
+// healthChecker checks nodes' health periodically until the pool is closed.
+func (p *Pool) healthChecker() {
+	ticker := time.NewTicker(p.opts.HealthCheckInterval)
+	defer func() {
+		ticker.Stop()
+		close(p.done)
+	}()
+	for {
+		select {
+		case <-p.shutdown:
+			return
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkHealth()
+		}
+	}
+}
+
+// checkHealth requests block count from all nodes and updates their state,
+// nodes that don't respond or lag more than MaxLag blocks behind the highest
+// one are marked as unhealthy.
+func (p *Pool) checkHealth() {
+	var (
+		counts = make([]uint32, len(p.nodes))
+		errs   = make([]error, len(p.nodes))
+		max    uint32
+		wg     sync.WaitGroup
+	)
+	for i := range p.nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			counts[i], errs[i] = p.nodes[i].client.GetBlockCount()
+		}(i)
+	}
+	wg.Wait()
+	for i := range counts {
+		if errs[i] == nil && counts[i] > max {
+			max = counts[i]
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, n := range p.nodes {
+		if errs[i] != nil {
+			n.healthy = false
+			continue
+		}
+		n.height = counts[i]
+		n.healthy = max-counts[i] <= p.opts.MaxLag
+	}
+}
+
+// pickNode returns the healthy node with the highest block count (the first
+// one specified wins if there are several of them). If there are no healthy
+// nodes it falls back to unhealthy ones (preferring the ones not yet tried for
+// the current request), so that a transient failure doesn't make the pool
+// unusable until the next health check.
+func (p *Pool) pickNode(tried map[*poolNode]bool) *poolNode {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var best, untried, highest *poolNode
+	for _, n := range p.nodes {
+		if n.healthy && (best == nil || n.height > best.height) {
+			best = n
+		}
+		if !tried[n] && (untried == nil || n.height > untried.height) {
+			untried = n
+		}
+		if highest == nil || n.height > highest.height {
+			highest = n
+		}
+	}
+	switch {
+	case best != nil:
+		return best
+	case untried != nil:
+		return untried
+	default:
+		return highest
+	}
+}
+
+// markFailed marks the node as unhealthy until the next successful health
+// check.
+func (p *Pool) markFailed(n *poolNode) {
+	p.lock.Lock()
+	n.healthy = false
+	p.lock.Unlock()
+}
+
+// withRetries calls f until it succeeds or returns an error response (that
+// is not retried) or the number of retries is exhausted waiting between
+// calls according to the pool options.
+func (p *Pool) withRetries(f func() error) error {
+	var delay = p.opts.RetryDelay
+	for i := 0; ; i++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		var rpcErr *response.Error
+		if errors.As(err, &rpcErr) || i >= p.opts.Retries {
+			return err
+		}
+		select {
+		case <-p.shutdown:
+			return err
+		case <-p.ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > p.opts.MaxRetryDelay {
+			delay = p.opts.MaxRetryDelay
+		}
+	}
+}
+
+// makePoolRequest sends the request to the best node retrying on failures,
+// sendrawtransaction is broadcasted to all nodes.
+func (p *Pool) makePoolRequest(r *request.Raw) (*response.Raw, error) {
+	var (
+		resp  *response.Raw
+		tried = make(map[*poolNode]bool)
+	)
+	err := p.withRetries(func() error {
+		var err error
+		if r.Method == "sendrawtransaction" {
+			resp, err = p.broadcast(r)
+			return err
+		}
+		n := p.pickNode(tried)
+		tried[n] = true
+		resp, err = n.client.requestF(r)
+		if err != nil {
+			p.markFailed(n)
+		}
+		return err
+	})
+	return resp, err
+}
+
+// makePoolBatchRequest sends the batch to the best node retrying on failures.
+func (p *Pool) makePoolBatchRequest(rs []*request.Raw) (response.Batch, error) {
+	var (
+		resps response.Batch
+		tried = make(map[*poolNode]bool)
+	)
+	err := p.withRetries(func() error {
+		n := p.pickNode(tried)
+		tried[n] = true
+		var err error
+		resps, err = n.client.batchF(rs)
+		if err != nil {
+			var rpcErr *response.Error
+			if !errors.As(err, &rpcErr) {
+				p.markFailed(n)
+			}
+		}
+		return err
+	})
+	return resps, err
+}
+
+// broadcast sends the request to all nodes of the pool (healthy or not). It
+// returns the first successful response if there is any, the first error
+// response if all nodes have rejected the request or the first error if no
+// node has responded at all.
+func (p *Pool) broadcast(r *request.Raw) (*response.Raw, error) {
+	var (
+		resps = make([]*response.Raw, len(p.nodes))
+		errs  = make([]error, len(p.nodes))
+		wg    sync.WaitGroup
+	)
+	for i := range p.nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resps[i], errs[i] = p.nodes[i].client.requestF(r)
+		}(i)
+	}
+	wg.Wait()
+
+	var (
+		rejected *response.Raw
+		err      error
+	)
+	for i := range p.nodes {
+		switch {
+		case errs[i] != nil:
+			p.markFailed(p.nodes[i])
+			if err == nil {
+				err = errs[i]
+			}
+		case resps[i].Error == nil:
+			return resps[i], nil
+		case rejected == nil:
+			rejected = resps[i]
+		}
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	return nil, err
+}
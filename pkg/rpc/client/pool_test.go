@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// poolTestNode is a stub node answering getblockcount with its height and
+// counting sendrawtransaction calls, it can also fail a number of requests.
+type poolTestNode struct {
+	*httptest.Server
+	height *atomic.Uint32
+	reject *atomic.Bool
+	sent   *atomic.Int32
+	fail   *atomic.Int32
+}
+
+func newPoolTestNode(t *testing.T, height uint32) *poolTestNode {
+	n := &poolTestNode{
+		height: atomic.NewUint32(height),
+		reject: atomic.NewBool(false),
+		sent:   atomic.NewInt32(0),
+		fail:   atomic.NewInt32(0),
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if n.fail.Dec() >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		r := request.NewIn()
+		require.NoError(t, r.DecodeData(req.Body))
+		var resp = `{"jsonrpc":"2.0","id":1,`
+		switch r.Method {
+		case "getblockcount":
+			resp += `"result":` + strconv.FormatUint(uint64(n.height.Load()), 10) + `}`
+		case "sendrawtransaction":
+			n.sent.Inc()
+			if n.reject.Load() {
+				resp += `"error":{"code":-501,"message":"Block or transaction already exists and cannot be sent repeatedly."}}`
+			} else {
+				resp += `"result":{"hash":"0x` + util.Uint256{1, 2, 3}.StringLE() + `"}}`
+			}
+		default:
+			t.Fatalf("unexpected request: %s", r.Method)
+		}
+		requestHandler(t, w, resp)
+	}))
+	return n
+}
+
+func TestPool(t *testing.T) {
+	nodes := []*poolTestNode{
+		newPoolTestNode(t, 10),
+		newPoolTestNode(t, 12),
+		newPoolTestNode(t, 11),
+	}
+	endpoints := make([]string, len(nodes))
+	for i := range nodes {
+		defer nodes[i].Close()
+		endpoints[i] = nodes[i].URL
+	}
+
+	p, err := NewPool(context.TODO(), endpoints, PoolOptions{
+		Options:             Options{Network: netmode.UnitTestNet},
+		HealthCheckInterval: time.Hour,
+		MaxLag:              1,
+		Retries:             1,
+		RetryDelay:          time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	checkHealthy := func(t *testing.T, expected ...bool) {
+		p.lock.RLock()
+		defer p.lock.RUnlock()
+		for i := range expected {
+			require.Equal(t, expected[i], p.nodes[i].healthy, "node %d", i)
+		}
+	}
+
+	t.Run("highest node", func(t *testing.T) {
+		checkHealthy(t, false, true, true)
+		count, err := p.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(12), count)
+	})
+	t.Run("catch up", func(t *testing.T) {
+		nodes[0].height.Store(13)
+		p.checkHealth()
+		checkHealthy(t, true, true, false)
+		count, err := p.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(13), count)
+	})
+	t.Run("broadcast", func(t *testing.T) {
+		nodes[0].reject.Store(true)
+		var resp result.RelayResult
+		require.NoError(t, p.performRequest("sendrawtransaction", request.NewRawParams(""), &resp))
+		require.Equal(t, util.Uint256{1, 2, 3}, resp.Hash)
+		for i := range nodes {
+			require.Equal(t, int32(1), nodes[i].sent.Load(), "node %d", i)
+		}
+
+		for i := range nodes {
+			nodes[i].reject.Store(true)
+		}
+		err := p.performRequest("sendrawtransaction", request.NewRawParams(""), &resp)
+		var rpcErr *response.Error
+		require.True(t, errors.As(err, &rpcErr))
+		for i := range nodes {
+			require.Equal(t, int32(2), nodes[i].sent.Load(), "node %d", i)
+		}
+	})
+	t.Run("retry", func(t *testing.T) {
+		p.checkHealth()
+		nodes[0].Close()
+		count, err := p.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(12), count)
+		checkHealthy(t, false, true, false)
+	})
+	t.Run("no healthy nodes", func(t *testing.T) {
+		nodes[1].Close()
+		nodes[2].Close()
+		p.checkHealth()
+		checkHealthy(t, false, false, false)
+		_, err := p.GetBlockCount()
+		require.Error(t, err)
+		var rpcErr *response.Error
+		require.False(t, errors.As(err, &rpcErr))
+	})
+}
+
+func TestPoolTransientFailure(t *testing.T) {
+	node := newPoolTestNode(t, 10)
+	defer node.Close()
+
+	p, err := NewPool(context.TODO(), []string{node.URL}, PoolOptions{
+		Options:             Options{Network: netmode.UnitTestNet},
+		HealthCheckInterval: time.Hour,
+		Retries:             1,
+		RetryDelay:          time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	// The node is marked as unhealthy after the first failure, but it's
+	// still used for retries and subsequent requests.
+	node.fail.Store(1)
+	count, err := p.GetBlockCount()
+	require.NoError(t, err)
+	require.Equal(t, uint32(10), count)
+	p.lock.RLock()
+	require.False(t, p.nodes[0].healthy)
+	p.lock.RUnlock()
+
+	count, err = p.GetBlockCount()
+	require.NoError(t, err)
+	require.Equal(t, uint32(10), count)
+
+	p.checkHealth()
+	p.lock.RLock()
+	require.True(t, p.nodes[0].healthy)
+	p.lock.RUnlock()
+}
+
+func TestNewPoolNoEndpoints(t *testing.T) {
+	_, err := NewPool(context.TODO(), nil, PoolOptions{})
+	require.Error(t, err)
+}
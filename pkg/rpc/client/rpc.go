@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -9,14 +10,57 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
+// CalculateNetworkFee returns network fee needed for the given transaction
+// calculated by the node. Witnesses of standard accounts are expected to have
+// verification scripts set, witnesses of contract-based accounts may have
+// invocation scripts pushing verify method arguments.
+func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	var (
+		params = request.NewRawParams(hex.EncodeToString(tx.Bytes()))
+		resp   = new(result.NetworkFee)
+	)
+	if err := c.performRequest("calculatenetworkfee", params, resp); err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
+// FindStates returns up to limit storage items of the given contract with keys
+// having the specified prefix and following start key (if it's not nil). Zero
+// limit means server-side maximum. Result is truncated if there are more items
+// available, in which case the last returned key can be used as a start for the
+// next call.
+func (c *Client) FindStates(contract util.Uint160, prefix, start []byte, limit int) (*result.FindStates, error) {
+	return c.findStates(request.NewRawParams(contract.StringLE(), hex.EncodeToString(prefix),
+		hex.EncodeToString(start), limit))
+}
+
+// FindStatesAtRoot is similar to FindStates, but it uses the MPT with the
+// specified state root as a data source and returns proofs for the first and
+// the last item found.
+func (c *Client) FindStatesAtRoot(stateroot util.Uint256, contract util.Uint160, prefix, start []byte, limit int) (*result.FindStates, error) {
+	return c.findStates(request.NewRawParams(contract.StringLE(), hex.EncodeToString(prefix),
+		hex.EncodeToString(start), limit, stateroot.StringLE()))
+}
+
+func (c *Client) findStates(params request.RawParams) (*result.FindStates, error) {
+	var resp = &result.FindStates{}
+	if err := c.performRequest("findstates", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns the contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256) (*result.ApplicationLog, error) {
 	var (
@@ -154,6 +198,25 @@ func (c *Client) GetBlockHeaderVerbose(hash util.Uint256) (*result.Header, error
 	return resp, nil
 }
 
+// GetBlockNotifications returns all notifications emitted by the block with
+// the given index (both during block persisting and by its successful
+// transactions) in the order they were generated. Filter is optional and can
+// be used to get only notifications from the given contract and/or with the
+// given name.
+func (c *Client) GetBlockNotifications(index uint32, filter *request.NotificationFilter) (*result.BlockNotifications, error) {
+	var (
+		params = request.NewRawParams(index)
+		resp   = &result.BlockNotifications{}
+	)
+	if filter != nil {
+		params.Values = append(params.Values, filter)
+	}
+	if err := c.performRequest("getblocknotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBlockSysFee returns the system fees of the block, based on the specified index.
 func (c *Client) GetBlockSysFee(index uint32) (util.Fixed8, error) {
 	var (
@@ -200,9 +263,32 @@ func (c *Client) GetNEP5Balances(address util.Uint160) (*result.NEP5Balances, er
 	return resp, nil
 }
 
-// GetNEP5Transfers is a wrapper for getnep5transfers RPC.
-func (c *Client) GetNEP5Transfers(address string) (*result.NEP5Transfers, error) {
+// GetNEP5Transfers is a wrapper for getnep5transfers RPC. Address parameter
+// is mandatory, while all the others are optional. Start and stop are
+// timestamps in milliseconds, limit and page are neo-go extensions. These
+// parameters are positional in the JSON-RPC call, you can't specify limit
+// and not specify start/stop for example. Transfers are returned from the
+// newest to the oldest one.
+func (c *Client) GetNEP5Transfers(address string, start, stop *uint64, limit, page *int) (*result.NEP5Transfers, error) {
 	params := request.NewRawParams(address)
+	if start != nil {
+		params.Values = append(params.Values, *start)
+		if stop != nil {
+			params.Values = append(params.Values, *stop)
+			if limit != nil {
+				params.Values = append(params.Values, *limit)
+				if page != nil {
+					params.Values = append(params.Values, *page)
+				}
+			} else if page != nil {
+				return nil, errors.New("bad parameters")
+			}
+		} else if limit != nil || page != nil {
+			return nil, errors.New("bad parameters")
+		}
+	} else if stop != nil || limit != nil || page != nil {
+		return nil, errors.New("bad parameters")
+	}
 	resp := new(result.NEP5Transfers)
 	if err := c.performRequest("getnep5transfers", params, resp); err != nil {
 		return nil, err
@@ -222,6 +308,19 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetProof returns existence proof of the storage item with the given key of
+// the given contract in the MPT with the specified state root.
+func (c *Client) GetProof(stateroot util.Uint256, contract util.Uint160, key []byte) (*result.GetProof, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), contract.StringLE(), hex.EncodeToString(key))
+		resp   = &result.GetProof{}
+	)
+	if err := c.performRequest("getproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawMemPool returns the list of unconfirmed transactions in memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var (
@@ -234,6 +333,19 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolDetails returns unconfirmed transactions in memory with their
+// senders, fees and verification status.
+func (c *Client) GetRawMemPoolDetails() (*result.RawMempoolDetails, error) {
+	var (
+		params = request.NewRawParams(1, 1)
+		resp   = new(result.RawMempoolDetails)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
 	var (
@@ -271,6 +383,39 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetStateHeight returns current block height and the height of the latest
+// state root stored without gaps.
+func (c *Client) GetStateHeight() (*result.StateHeight, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = &result.StateHeight{}
+	)
+	if err := c.performRequest("getstateheight", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStateRootByHeight returns state root together with its verification
+// state for the specified height.
+func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRootState, error) {
+	return c.getStateRoot(request.NewRawParams(height))
+}
+
+// GetStateRootByBlockHash returns state root together with its verification
+// state for the block with the specified hash.
+func (c *Client) GetStateRootByBlockHash(hash util.Uint256) (*state.MPTRootState, error) {
+	return c.getStateRoot(request.NewRawParams(hash.StringLE()))
+}
+
+func (c *Client) getStateRoot(params request.RawParams) (*state.MPTRootState, error) {
+	var resp = &state.MPTRootState{}
+	if err := c.performRequest("getstateroot", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByID returns the stored value, according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage(request.NewRawParams(id, hex.EncodeToString(key)))
@@ -317,6 +462,43 @@ func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	return resp, nil
 }
 
+// GetCommittee returns the current NEO committee members.
+func (c *Client) GetCommittee() (keys.PublicKeys, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(keys.PublicKeys)
+	)
+	if err := c.performRequest("getcommittee", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetNextBlockValidators returns validators of the next block with their votes.
+func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Validator)
+	)
+	if err := c.performRequest("getnextblockvalidators", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetCandidates returns all registered candidates with their votes, Active
+// flag is set for the ones that are next block validators.
+func (c *Client) GetCandidates() ([]result.Validator, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Validator)
+	)
+	if err := c.performRequest("getcandidates", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
 // GetValidators returns the current NEO consensus nodes information and voting status.
 func (c *Client) GetValidators() ([]result.Validator, error) {
 	var (
@@ -356,6 +538,80 @@ func (c *Client) InvokeFunction(contract util.Uint160, operation string, params
 	return c.invokeSomething("invokefunction", p, signers)
 }
 
+// InvokeScriptAtHeight is similar to InvokeScript, but it uses contract
+// storage state as of the block with the specified index.
+func (c *Client) InvokeScriptAtHeight(height uint32, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(hex.EncodeToString(script))
+	return c.invokeSomethingHistoric("invokescript", p, signers, height)
+}
+
+// InvokeScriptWithState is similar to InvokeScript, but it uses contract
+// storage state from the MPT with the specified state root.
+func (c *Client) InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(hex.EncodeToString(script))
+	return c.invokeSomethingHistoric("invokescript", p, signers, stateroot.StringLE())
+}
+
+// InvokeFunctionAtHeight is similar to InvokeFunction, but it uses contract
+// storage state as of the block with the specified index.
+func (c *Client) InvokeFunctionAtHeight(height uint32, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(contract.StringLE(), operation, params)
+	return c.invokeSomethingHistoric("invokefunction", p, signers, height)
+}
+
+// InvokeFunctionWithState is similar to InvokeFunction, but it uses contract
+// storage state from the MPT with the specified state root.
+func (c *Client) InvokeFunctionWithState(stateroot util.Uint256, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(contract.StringLE(), operation, params)
+	return c.invokeSomethingHistoric("invokefunction", p, signers, stateroot.StringLE())
+}
+
+// InvokeScriptWithTrace is similar to InvokeScript, but it also returns
+// execution trace (contract calls, storage changes, notifications, logs and
+// exception location) in the Trace field of the result.
+func (c *Client) InvokeScriptWithTrace(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(hex.EncodeToString(script))
+	return c.invokeSomethingTraced("invokescript", p, signers)
+}
+
+// InvokeFunctionWithTrace is similar to InvokeFunction, but it also returns
+// execution trace in the Trace field of the result.
+func (c *Client) InvokeFunctionWithTrace(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(contract.StringLE(), operation, params)
+	return c.invokeSomethingTraced("invokefunction", p, signers)
+}
+
+// InvokeContractVerify returns the results of the given contract's verify
+// method run with the given parameters in the Verification trigger.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	if params == nil {
+		params = []smartcontract.Parameter{}
+	}
+	var p = request.NewRawParams(contract.StringLE(), params)
+	return c.invokeSomething("invokecontractverify", p, signers)
+}
+
+// invokeSomethingHistoric is an inner wrapper for historic Invoke* functions,
+// state is either a block index or a state root.
+func (c *Client) invokeSomethingHistoric(method string, p request.RawParams, signers []transaction.Signer, state interface{}) (*result.Invoke, error) {
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	p.Values = append(p.Values, signers, state)
+	return c.invokeSomething(method, p, nil)
+}
+
+// invokeSomethingTraced is an inner wrapper for traced Invoke* functions, it
+// uses current state (null state root) and sets trace flag.
+func (c *Client) invokeSomethingTraced(method string, p request.RawParams, signers []transaction.Signer) (*result.Invoke, error) {
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	p.Values = append(p.Values, signers, nil, 1)
+	return c.invokeSomething(method, p, nil)
+}
+
 // invokeSomething is an inner wrapper for Invoke* functions
 func (c *Client) invokeSomething(method string, p request.RawParams, signers []transaction.Signer) (*result.Invoke, error) {
 	var resp = new(result.Invoke)
@@ -383,6 +639,20 @@ func (c *Client) SendRawTransaction(rawTX *transaction.Transaction) (util.Uint25
 	return resp.Hash, nil
 }
 
+// TestSendRawTransaction checks whether the transaction would be accepted by
+// the node without adding it to the mempool and relaying. Verification
+// failures are returned as a part of the result with the reason set.
+func (c *Client) TestSendRawTransaction(rawTX *transaction.Transaction) (*result.TxVerification, error) {
+	var (
+		params = request.NewRawParams(hex.EncodeToString(rawTX.Bytes()))
+		resp   = new(result.TxVerification)
+	)
+	if err := c.performRequest("testsendrawtransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // SubmitBlock broadcasts a raw block over the NEO network.
 func (c *Client) SubmitBlock(b block.Block) (util.Uint256, error) {
 	var (
@@ -445,6 +715,44 @@ func getSigners(sender util.Uint160, cosigners []transaction.Signer) []transacti
 	return append([]transaction.Signer{s}, cosigners...)
 }
 
+// TerminateSession closes iterator session with the given ID on the server,
+// it returns false if there is no such session (it may be expired already).
+func (c *Client) TerminateSession(sessionID string) (bool, error) {
+	var (
+		params = request.NewRawParams(sessionID)
+		resp   bool
+	)
+	if err := c.performRequest("terminatesession", params, &resp); err != nil {
+		return false, err
+	}
+	return resp, nil
+}
+
+// TraverseIterator returns at most maxItems next values of the iterator with
+// the given ID from the given session. Both IDs are taken from the invocation
+// result, iterators are represented there by Interop stack items with
+// result.Iterator values. Iterator values are returned as structs of key and
+// value, enumerator ones are returned as is. Empty result means that the
+// iterator is exhausted.
+func (c *Client) TraverseIterator(sessionID, iteratorID string, maxItems int) ([]stackitem.Item, error) {
+	var (
+		params = request.NewRawParams(sessionID, iteratorID, maxItems)
+		resp   []json.RawMessage
+	)
+	if err := c.performRequest("traverseiterator", params, &resp); err != nil {
+		return nil, err
+	}
+	items := make([]stackitem.Item, len(resp))
+	for i := range resp {
+		var err error
+		items[i], err = stackitem.FromJSONWithTypes(resp[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode iterator value #%d: %w", i, err)
+		}
+	}
+	return items, nil
+}
+
 // ValidateAddress verifies that the address is a correct NEO address.
 func (c *Client) ValidateAddress(address string) error {
 	var (
@@ -461,6 +769,19 @@ func (c *Client) ValidateAddress(address string) error {
 	return nil
 }
 
+// VerifyProof returns value by the given stateroot and proof. The result is
+// nil if the proof is invalid.
+func (c *Client) VerifyProof(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), proof.String())
+		resp   = &result.VerifyProof{}
+	)
+	if err := c.performRequest("verifyproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
 // CalculateValidUntilBlock calculates ValidUntilBlock field for tx as
 // current blockchain height + number of validators. Number of validators
 // is the length of blockchain validators list got from GetValidators()
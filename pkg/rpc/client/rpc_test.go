@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
@@ -101,6 +103,59 @@ func getTxMoveNeo() *result.TransactionOutputRaw {
 // published in official C# JSON-RPC API v2.10.3 reference
 // (see https://docs.neo.org/docs/en-us/reference/rpc/latest-version/api.html)
 var rpcClientTestCases = map[string][]rpcClientTestCase{
+	"calculatenetworkfee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+				tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+				return c.CalculateNetworkFee(tx)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"networkfee":"1234567"}}`,
+			result: func(c *Client) interface{} {
+				return int64(1234567)
+			},
+		},
+	},
+	"findstates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				cHash, _ := util.Uint160DecodeStringLE("c4b3a2d1e0f1c4b3a2d1e0f1c4b3a2d1e0f1c4b3")
+				return c.FindStates(cHash, []byte("ab"), nil, 2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"results":[{"key":"6162","value":"01"},{"key":"616263","value":"02"}],"truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return &result.FindStates{
+					Results: []result.KeyValue{
+						{Key: []byte("ab"), Value: []byte{1}},
+						{Key: []byte("abc"), Value: []byte{2}},
+					},
+					Truncated: true,
+				}
+			},
+		},
+		{
+			name: "positive, at root",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("e1e0b1a7a3f0f1e0d1c1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1")
+				cHash, _ := util.Uint160DecodeStringLE("c4b3a2d1e0f1c4b3a2d1e0f1c4b3a2d1e0f1c4b3")
+				return c.FindStatesAtRoot(root, cHash, []byte("ab"), []byte("ab"), 0)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"results":[{"key":"616263","value":"02"}],"firstProof":"0401020304020362f58d0142","lastProof":"0401020304020362f58d0142","truncated":false}}`,
+			result: func(c *Client) interface{} {
+				p := &result.ProofWithKey{
+					Key:   []byte{1, 2, 3, 4},
+					Proof: [][]byte{{0x62, 0xf5, 0x8d}, {0x42}},
+				}
+				return &result.FindStates{
+					Results:    []result.KeyValue{{Key: []byte("abc"), Value: []byte{2}}},
+					FirstProof: p,
+					LastProof:  p,
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name: "positive",
@@ -264,6 +319,48 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblocknotifications": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				name := "transfer"
+				return c.GetBlockNotifications(6, &request.NotificationFilter{Name: &name})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xa40ca47711b5e45f141f3ff8526e6682915782cd746bf75c2389f6bfffa3752c","index":6,"notifications":[{"container":"0x981e6f2fc3363f202d357c5f41c2a2f523e98812439d3633a4ed031a896e8d4e","contract":"0x93c4983afe01a75f74c1e56011bd630e9d8cc755","eventname":"transfer","state":{"type":"Array","value":[{"type":"Integer","value":"1"}]}}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint256DecodeStringLE("a40ca47711b5e45f141f3ff8526e6682915782cd746bf75c2389f6bfffa3752c")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("981e6f2fc3363f202d357c5f41c2a2f523e98812439d3633a4ed031a896e8d4e")
+				if err != nil {
+					panic(err)
+				}
+				contract, err := util.Uint160DecodeStringLE("93c4983afe01a75f74c1e56011bd630e9d8cc755")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockNotifications{
+					Hash:  hash,
+					Index: 6,
+					Notifications: []result.ContainedNotificationEvent{{
+						Container: txHash,
+						NotificationEvent: result.NotificationEvent{
+							Contract: contract,
+							Name:     "transfer",
+							Item: smartcontract.Parameter{
+								Type: smartcontract.ArrayType,
+								Value: []smartcontract.Parameter{{
+									Type:  smartcontract.IntegerType,
+									Value: int64(1),
+								}},
+							},
+						},
+					}},
+				}
+			},
+		},
+	},
 	"getblocksysfee": {
 		{
 			name: "positive",
@@ -395,7 +492,7 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 		{
 			name: "positive",
 			invoke: func(c *Client) (interface{}, error) {
-				return c.GetNEP5Transfers("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF")
+				return c.GetNEP5Transfers("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF", nil, nil, nil, nil)
 			},
 			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[{"timestamp":1555651816,"assethash":"600c4f5200db36177e3e8a09e9f18e2fc7d12a0f","transferaddress":"AYwgBNMepiv5ocGcyNT4mA8zPLTQ8pDBis","amount":"1000000","blockindex":436036,"transfernotifyindex":0,"txhash":"df7683ece554ecfb85cf41492c5f143215dd43ef9ec61181a28f922da06aba58"}],"address":"AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF"}}`,
 			result: func(c *Client) interface{} {
@@ -456,6 +553,26 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("e1e0b1a7a3f0f1e0d1c1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1")
+				cHash, _ := util.Uint160DecodeStringLE("c4b3a2d1e0f1c4b3a2d1e0f1c4b3a2d1e0f1c4b3")
+				return c.GetProof(root, cHash, []byte{1, 2, 3})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"proof":"0401020304020362f58d0142","success":true}}`,
+			result: func(c *Client) interface{} {
+				return &result.GetProof{
+					Result: result.ProofWithKey{
+						Key:   []byte{1, 2, 3, 4},
+						Proof: [][]byte{{0x62, 0xf5, 0x8d}, {0x42}},
+					},
+					Success: true,
+				}
+			},
+		},
+	},
 	"getrawmempool": {
 		{
 			name: "positive",
@@ -471,6 +588,34 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return []util.Uint256{hash}
 			},
 		},
+		{
+			name: "positive, details",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetRawMemPoolDetails()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"transactions":[{"hash":"0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e","sender":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","sysfee":"10000000","netfee":"4488350","validuntilblock":1200,"verified":true}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				sender, err := address.StringToUint160("NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawMempoolDetails{
+					Height: 5,
+					Transactions: []result.MempoolTransaction{{
+						Hash:            hash,
+						Sender:          sender,
+						SystemFee:       10000000,
+						NetworkFee:      4488350,
+						ValidUntilBlock: 1200,
+						Verified:        true,
+					}},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -508,6 +653,47 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getstateheight": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateHeight()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockHeight":208,"stateHeight":200}}`,
+			result: func(c *Client) interface{} {
+				return &result.StateHeight{
+					BlockHeight: 208,
+					StateHeight: 200,
+				}
+			},
+		},
+	},
+	"getstateroot": {
+		{
+			name: "positive, by height",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateRootByHeight(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"stateroot":{"version":0,"index":5,"prehash":"0x3959b2ae0eb8d1c1a4c2c0e8a69c1d07a19a1ef1f1b1e4f4a8d1c9b1d0c2e1f0","stateroot":"0x7e1e7e5b1a4d2a3c5e9c8d2f5b2e1a4d8c7b6a5e4d3c2b1a0f9e8d7c6b5a4f3e"},"flag":"Verified"}}`,
+			result: func(c *Client) interface{} {
+				return expectedStateRoot()
+			},
+		},
+		{
+			name: "positive, by hash",
+			invoke: func(c *Client) (interface{}, error) {
+				h, err := util.Uint256DecodeStringLE("d4f26d1c7a9d0dc8fc9f8fde8cd2b6d4a1dcd3b8b8cb4b4c1e0c9e3b3c0f1f1a")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetStateRootByBlockHash(h)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"stateroot":{"version":0,"index":5,"prehash":"0x3959b2ae0eb8d1c1a4c2c0e8a69c1d07a19a1ef1f1b1e4f4a8d1c9b1d0c2e1f0","stateroot":"0x7e1e7e5b1a4d2a3c5e9c8d2f5b2e1a4d8c7b6a5e4d3c2b1a0f9e8d7c6b5a4f3e"},"flag":"Verified"}}`,
+			result: func(c *Client) interface{} {
+				return expectedStateRoot()
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "by hash, positive",
@@ -585,6 +771,62 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCandidates()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"100","active":true},{"publickey":"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","votes":"0","active":false}]}`,
+			result: func(c *Client) interface{} {
+				return []result.Validator{
+					{
+						PublicKey: *mustPublicKey("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"),
+						Votes:     100,
+						Active:    true,
+					},
+					{
+						PublicKey: *mustPublicKey("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"),
+						Votes:     0,
+						Active:    false,
+					},
+				}
+			},
+		},
+	},
+	"getcommittee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCommittee()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"]}`,
+			result: func(c *Client) interface{} {
+				return keys.PublicKeys{
+					mustPublicKey("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"),
+					mustPublicKey("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"),
+				}
+			},
+		},
+	},
+	"getnextblockvalidators": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextBlockValidators()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"100","active":true}]}`,
+			result: func(c *Client) interface{} {
+				return []result.Validator{
+					{
+						PublicKey: *mustPublicKey("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"),
+						Votes:     100,
+						Active:    true,
+					},
+				}
+			},
+		},
+	},
 	"getvalidators": {
 		{
 			name: "positive",
@@ -653,6 +895,30 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"invokecontractverify": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				contr, err := util.Uint160DecodeStringLE("af7c7328eee5a275a3bcaee2bf0cf662b5e739be")
+				if err != nil {
+					panic(err)
+				}
+				return c.InvokeContractVerify(contr, []smartcontract.Parameter{{
+					Type:  smartcontract.IntegerType,
+					Value: int64(4),
+				}}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"14","state":"HALT","gasconsumed":"1570","stack":[{"type":"Boolean","value":true}]}}`,
+			result: func(c *Client) interface{} {
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 1570,
+					Script:      "14",
+					Stack:       []stackitem.Item{stackitem.NewBool(true)},
+				}
+			},
+		},
+	},
 	"invokescript": {
 		{
 			name: "positive",
@@ -679,6 +945,56 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, traced",
+			invoke: func(c *Client) (interface{}, error) {
+				script, err := hex.DecodeString("00046e616d656724058e5e1b6008847cd662728549088a9ee82191")
+				if err != nil {
+					panic(err)
+				}
+				return c.InvokeScriptWithTrace(script, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"00046e616d656724058e5e1b6008847cd662728549088a9ee82191","state":"FAULT","gasconsumed":"16100000","stack":[],"trace":{"calls":{"contract":"0x1b5e8e0524676d616e046e00d262728549088a9e","gasconsumed":"16100000","calls":[{"contract":"0x9ee82191088a9e2872629d660884601b5e8e0524","method":"name","gasconsumed":"1000000"}]},"storage":[{"contract":"0x9ee82191088a9e2872629d660884601b5e8e0524","id":1,"key":"0102","old":null,"new":"03"},{"contract":"0x9ee82191088a9e2872629d660884601b5e8e0524","id":1,"key":"0405","old":"06","new":null,"deleted":true}],"notifications":[],"logs":[{"contract":"0x9ee82191088a9e2872629d660884601b5e8e0524","message":"hello"}],"exception":{"contract":"0x1b5e8e0524676d616e046e00d262728549088a9e","ip":27,"opcode":"ABORT","message":"ABORT"}}}}`,
+			result: func(c *Client) interface{} {
+				root, err := util.Uint160DecodeStringLE("1b5e8e0524676d616e046e00d262728549088a9e")
+				if err != nil {
+					panic(err)
+				}
+				contr, err := util.Uint160DecodeStringLE("9ee82191088a9e2872629d660884601b5e8e0524")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:       "FAULT",
+					GasConsumed: 16100000,
+					Script:      "00046e616d656724058e5e1b6008847cd662728549088a9ee82191",
+					Stack:       []stackitem.Item{},
+					Trace: &result.Trace{
+						Calls: &result.CallFrame{
+							Contract:    root,
+							GasConsumed: 16100000,
+							Calls: []result.CallFrame{{
+								Contract:    contr,
+								Method:      "name",
+								GasConsumed: 1000000,
+							}},
+						},
+						Storage: []result.StorageChange{
+							{Contract: contr, ID: 1, Key: []byte{1, 2}, NewValue: []byte{3}},
+							{Contract: contr, ID: 1, Key: []byte{4, 5}, OldValue: []byte{6}, Deleted: true},
+						},
+						Notifications: []result.NotificationEvent{},
+						Logs:          []result.LogEvent{{Contract: contr, Message: "hello"}},
+						Exception: &result.ExceptionLocation{
+							Contract: root,
+							IP:       27,
+							Opcode:   opcode.ABORT,
+							Message:  "ABORT",
+						},
+					},
+				}
+			},
+		},
 	},
 	"sendrawtransaction": {
 		{
@@ -696,6 +1012,42 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"testsendrawtransaction": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TestSendRawTransaction(transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xf5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5f48","valid":true}}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5f48")
+				if err != nil {
+					panic(err)
+				}
+				return &result.TxVerification{Hash: h, Valid: true}
+			},
+		},
+		{
+			name: "invalid witness",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TestSendRawTransaction(transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xf5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5f48","valid":false,"reason":"witness","signer":1,"message":"witness #1: signature check failed: invalid signature"}}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5f48")
+				if err != nil {
+					panic(err)
+				}
+				signer := 1
+				return &result.TxVerification{
+					Hash:    h,
+					Reason:  result.TxWitness,
+					Signer:  &signer,
+					Message: "witness #1: signature check failed: invalid signature",
+				}
+			},
+		},
+	},
 	"submitblock": {
 		{
 			name: "positive",
@@ -716,6 +1068,59 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"verifyproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("e1e0b1a7a3f0f1e0d1c1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1c1d1a1b1e1f1")
+				return c.VerifyProof(root, &result.ProofWithKey{
+					Key:   []byte{1, 2, 3, 4},
+					Proof: [][]byte{{0x62, 0xf5, 0x8d}, {0x42}},
+				})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"7465737476616c7565"}`,
+			result: func(c *Client) interface{} {
+				return []byte("testvalue")
+			},
+		},
+		{
+			name: "invalid",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.VerifyProof(util.Uint256{}, &result.ProofWithKey{})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"invalid"}`,
+			result: func(c *Client) interface{} {
+				return []byte(nil)
+			},
+		},
+	},
+	"terminatesession": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TerminateSession("a7b6b6b1-6c3a-4d5e-8f4a-3b2c1d0e9f8a")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
+	"traverseiterator": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TraverseIterator("a7b6b6b1-6c3a-4d5e-8f4a-3b2c1d0e9f8a", "0f1e2d3c-4b5a-4978-8a6b-5c4d3e2f1a0b", 2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"type":"Struct","value":[{"type":"ByteString","value":"AQ=="},{"type":"Integer","value":"42"}]},{"type":"Boolean","value":true}]}`,
+			result: func(c *Client) interface{} {
+				return []stackitem.Item{
+					stackitem.NewStruct([]stackitem.Item{stackitem.NewByteArray([]byte{1}), stackitem.Make(42)}),
+					stackitem.NewBool(true),
+				}
+			},
+		},
+	},
 	"validateaddress": {
 		{
 			name: "positive",
@@ -923,7 +1328,30 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 		{
 			name: "getnep5transfers_invalid_params_error",
 			invoke: func(c *Client) (interface{}, error) {
-				return c.GetNEP5Transfers("")
+				return c.GetNEP5Transfers("", nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "getnep5transfers_invalid_params_error 2",
+			invoke: func(c *Client) (interface{}, error) {
+				var stop uint64
+				return c.GetNEP5Transfers("", nil, &stop, nil, nil)
+			},
+		},
+		{
+			name: "getnep5transfers_invalid_params_error 3",
+			invoke: func(c *Client) (interface{}, error) {
+				var start uint64
+				var limit int
+				return c.GetNEP5Transfers("", &start, nil, &limit, nil)
+			},
+		},
+		{
+			name: "getnep5transfers_invalid_params_error 4",
+			invoke: func(c *Client) (interface{}, error) {
+				var start, stop uint64
+				var page int
+				return c.GetNEP5Transfers("", &start, &stop, nil, &page)
 			},
 		},
 		{
@@ -1081,7 +1509,7 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 		{
 			name: "getnep5transfers_unmarshalling_error",
 			invoke: func(c *Client) (interface{}, error) {
-				return c.GetNEP5Transfers("")
+				return c.GetNEP5Transfers("", nil, nil, nil, nil)
 			},
 		},
 		{
@@ -1330,3 +1758,93 @@ func TestCalculateValidUntilBlock(t *testing.T) {
 	assert.Equal(t, 2, getBlockCountCalled)
 	assert.Equal(t, 1, getValidatorsCalled)
 }
+
+func TestGetNativeContractHash(t *testing.T) {
+	natives := []state.NativeContract{
+		{
+			ID:       -1,
+			Hash:     util.Uint160{1, 2, 3},
+			Name:     "NEO",
+			Script:   []byte{1},
+			Manifest: *manifest.DefaultManifest(util.Uint160{1, 2, 3}),
+			Methods: []state.NativeMethod{
+				{Name: "balanceOf", Price: 1000000, RequiredFlags: smartcontract.AllowStates},
+			},
+		},
+		{
+			ID:       -2,
+			Hash:     util.Uint160{4, 5, 6},
+			Name:     "GAS",
+			Script:   []byte{2},
+			Manifest: *manifest.DefaultManifest(util.Uint160{4, 5, 6}),
+			Methods:  []state.NativeMethod{},
+		},
+	}
+	data, err := json.Marshal(natives)
+	require.NoError(t, err)
+
+	var getNativeContractsCalled int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewIn()
+		err := r.DecodeData(req.Body)
+		if err != nil {
+			t.Fatalf("Cannot decode request body: %s", req.Body)
+		}
+		if r.Method != "getnativecontracts" {
+			t.Fatalf("Bad request method: %s", r.Method)
+		}
+		getNativeContractsCalled++
+		requestHandler(t, w, `{"jsonrpc":"2.0","id":1,"result":`+string(data)+`}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	actual, err := c.GetNativeContracts()
+	require.NoError(t, err)
+	require.Equal(t, natives, actual)
+	require.Equal(t, 1, getNativeContractsCalled)
+
+	h, err := c.GetNativeContractHash("GAS")
+	require.NoError(t, err)
+	require.Equal(t, util.Uint160{4, 5, 6}, h)
+	require.Equal(t, 2, getNativeContractsCalled)
+
+	// Cached value is used.
+	nc, err := c.GetNativeContract("NEO")
+	require.NoError(t, err)
+	require.Equal(t, &natives[0], nc)
+	_, err = c.GetNativeContractHash("Policy")
+	require.Error(t, err)
+	require.Equal(t, 2, getNativeContractsCalled)
+}
+
+func mustPublicKey(s string) *keys.PublicKey {
+	pub, err := keys.NewPublicKeyFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return pub
+}
+
+func expectedStateRoot() *state.MPTRootState {
+	prev, err := util.Uint256DecodeStringLE("3959b2ae0eb8d1c1a4c2c0e8a69c1d07a19a1ef1f1b1e4f4a8d1c9b1d0c2e1f0")
+	if err != nil {
+		panic(err)
+	}
+	root, err := util.Uint256DecodeStringLE("7e1e7e5b1a4d2a3c5e9c8d2f5b2e1a4d8c7b6a5e4d3c2b1a0f9e8d7c6b5a4f3e")
+	if err != nil {
+		panic(err)
+	}
+	return &state.MPTRootState{
+		MPTRoot: state.MPTRoot{
+			MPTRootBase: state.MPTRootBase{
+				Index:    5,
+				PrevHash: prev,
+				Root:     root,
+			},
+		},
+		Flag: state.Verified,
+	}
+}
@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrTxNotAccepted is returned by WaitForTransaction when the transaction
+// can't be accepted anymore because its ValidUntilBlock has passed.
+var ErrTxNotAccepted = errors.New("transaction was not accepted before ValidUntilBlock")
+
+var (
+	// waitPollInterval is the interval between checks made by
+	// Client.WaitForTransaction.
+	waitPollInterval = time.Second
+	// wsWaitPollInterval is the interval between checks made by
+	// WSClient.WaitForTransaction, they're only needed to handle
+	// ValidUntilBlock and executions missed during reconnection.
+	wsWaitPollInterval = 5 * time.Second
+)
+
+// WaitForTransaction waits for the transaction with the given hash to be
+// included into a block and returns its application log. It polls the node
+// until the transaction is found, the context is done or the block with
+// validUntilBlock index is persisted without the transaction (then
+// ErrTxNotAccepted is returned, zero validUntilBlock disables this check).
+func (c *Client) WaitForTransaction(ctx context.Context, hash util.Uint256, validUntilBlock uint32) (*result.ApplicationLog, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		log, err := c.checkTransaction(hash, validUntilBlock)
+		if log != nil || err != nil {
+			return log, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForTransaction is the same as Client.WaitForTransaction, but it uses
+// `transaction_executed` event subscription to get the application log as
+// soon as possible, polling the node only occasionally. This subscription is
+// internal, its events are not sent to Notifications channel (unless they
+// match user's subscriptions). If the subscription can't be made, it falls
+// back to polling.
+func (c *WSClient) WaitForTransaction(ctx context.Context, hash util.Uint256, validUntilBlock uint32) (*result.ApplicationLog, error) {
+	ch, err := c.addExecWaiter(hash)
+	if err != nil {
+		return c.Client.WaitForTransaction(ctx, hash, validUntilBlock)
+	}
+	defer c.removeExecWaiter(hash, ch)
+
+	ticker := time.NewTicker(wsWaitPollInterval)
+	defer ticker.Stop()
+	for {
+		// The transaction could've been executed before subscription or
+		// during reconnection, so polling is still needed.
+		log, err := c.checkTransaction(hash, validUntilBlock)
+		if log != nil || err != nil {
+			return log, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.done:
+			return nil, errConnLost
+		case log := <-ch:
+			return log, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkTransaction returns the application log of the transaction or an
+// error if it's not accepted before validUntilBlock. Both return values are
+// nil if the transaction is not yet found.
+func (c *Client) checkTransaction(hash util.Uint256, validUntilBlock uint32) (*result.ApplicationLog, error) {
+	log, err := c.GetApplicationLog(hash)
+	if err == nil {
+		return log, nil
+	}
+	if validUntilBlock == 0 {
+		return nil, nil
+	}
+	count, err := c.GetBlockCount()
+	if err != nil || count <= validUntilBlock {
+		return nil, nil
+	}
+	// The block with validUntilBlock index is persisted, so the
+	// transaction can be in it (but not in any other after it).
+	log, err = c.GetApplicationLog(hash)
+	if err == nil {
+		return log, nil
+	}
+	var rpcErr *response.Error
+	if errors.As(err, &rpcErr) {
+		return nil, fmt.Errorf("%w: current height is %d, transaction is valid until %d", ErrTxNotAccepted, count-1, validUntilBlock)
+	}
+	return nil, nil
+}
+
+// addExecWaiter registers the channel for the transaction execution result,
+// making an internal `transaction_executed` subscription if needed.
+func (c *WSClient) addExecWaiter(hash util.Uint256) (chan *result.ApplicationLog, error) {
+	c.execSubLock.Lock()
+	defer c.execSubLock.Unlock()
+
+	c.waitersLock.Lock()
+	subscribed := c.execSubID != ""
+	c.waitersLock.Unlock()
+	if !subscribed {
+		var id string
+		if err := c.performRequest("subscribe", request.NewRawParams("transaction_executed"), &id); err != nil {
+			return nil, err
+		}
+		c.waitersLock.Lock()
+		c.execSubID = id
+		c.waitersLock.Unlock()
+	}
+
+	ch := make(chan *result.ApplicationLog, 1)
+	c.waitersLock.Lock()
+	c.execWaiters[hash] = append(c.execWaiters[hash], ch)
+	c.waitersLock.Unlock()
+	return ch, nil
+}
+
+// removeExecWaiter removes the channel added by addExecWaiter, internal
+// subscription is removed along with the last waiter.
+func (c *WSClient) removeExecWaiter(hash util.Uint256, ch chan *result.ApplicationLog) {
+	c.execSubLock.Lock()
+	defer c.execSubLock.Unlock()
+
+	c.waitersLock.Lock()
+	chs := c.execWaiters[hash]
+	for i := range chs {
+		if chs[i] == ch {
+			chs = append(chs[:i], chs[i+1:]...)
+			break
+		}
+	}
+	if len(chs) == 0 {
+		delete(c.execWaiters, hash)
+	} else {
+		c.execWaiters[hash] = chs
+	}
+	id := c.execSubID
+	last := len(c.execWaiters) == 0
+	c.waitersLock.Unlock()
+	if !last || id == "" {
+		return
+	}
+	// Events are still routed to waiters until unsubscription is complete,
+	// so that they're not sent to Notifications.
+	var resp bool
+	_ = c.performRequest("unsubscribe", request.NewRawParams(id), &resp)
+	c.waitersLock.Lock()
+	c.execSubID = ""
+	c.waitersLock.Unlock()
+}
+
+// restoreExecSubscription makes internal subscription again after
+// reconnection if there are active waiters. It returns false if the
+// connection is lost in the process.
+func (c *WSClient) restoreExecSubscription(conn *wsConn) bool {
+	c.execSubLock.Lock()
+	defer c.execSubLock.Unlock()
+
+	c.waitersLock.Lock()
+	subscribed := c.execSubID != ""
+	c.waitersLock.Unlock()
+	if !subscribed {
+		return true
+	}
+	var id string
+	if err := c.performRequest("subscribe", request.NewRawParams("transaction_executed"), &id); err != nil {
+		// Waiters will still get results by polling.
+		return !isClosed(conn.done)
+	}
+	c.waitersLock.Lock()
+	c.execSubID = id
+	c.waitersLock.Unlock()
+	return true
+}
+
+// notifyExecWaiters passes the execution result to waiters of its
+// transaction. It returns true if the event is to be sent to Notifications
+// channel, which is not the case for events caused only by the internal
+// subscription.
+func (c *WSClient) notifyExecWaiters(log *result.ApplicationLog) bool {
+	c.waitersLock.Lock()
+	for _, ch := range c.execWaiters[log.TxHash] {
+		select {
+		case ch <- log:
+		default:
+		}
+	}
+	internal := c.execSubID != ""
+	c.waitersLock.Unlock()
+	if !internal {
+		return true
+	}
+
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+	for _, sub := range c.subscriptions {
+		params := sub.params
+		if len(params.Values) == 0 || params.Values[0] != "transaction_executed" {
+			continue
+		}
+		if len(params.Values) == 1 || params.Values[1] == nil {
+			return true
+		}
+		switch f := params.Values[1].(type) {
+		case request.ExecutionFilter:
+			if executionFilterMatches(f, log) {
+				return true
+			}
+		case []interface{}:
+			for i := range f {
+				if ef, ok := f[i].(request.ExecutionFilter); ok && executionFilterMatches(ef, log) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// executionFilterMatches checks the execution result against the filter the
+// same way the server does.
+func executionFilterMatches(f request.ExecutionFilter, log *result.ApplicationLog) bool {
+	if f.State != "" && log.VMState != f.State {
+		return false
+	}
+	if f.Contract == nil {
+		return true
+	}
+	for i := range log.Events {
+		if log.Events[i].Contract.Equals(*f.Contract) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// setWaitPollIntervals changes polling intervals returning a function to
+// restore them.
+func setWaitPollIntervals(d time.Duration) func() {
+	old, oldWS := waitPollInterval, wsWaitPollInterval
+	waitPollInterval, wsWaitPollInterval = d, d
+	return func() { waitPollInterval, wsWaitPollInterval = old, oldWS }
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	defer setWaitPollIntervals(10 * time.Millisecond)()
+
+	var (
+		hash   = util.Uint256{1, 2, 3}
+		height = atomic.NewUint32(10)
+		found  = atomic.NewBool(false)
+	)
+	applog, err := json.Marshal(result.ApplicationLog{TxHash: hash, Trigger: "Application", VMState: "HALT", Stack: []stackitem.Item{}})
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewIn()
+		require.NoError(t, r.DecodeData(req.Body))
+		var resp = `{"jsonrpc":"2.0","id":1,`
+		switch r.Method {
+		case "getblockcount":
+			resp += `"result":` + strconv.FormatUint(uint64(height.Load()), 10) + `}`
+		case "getapplicationlog":
+			if found.Load() {
+				resp += `"result":` + string(applog) + `}`
+			} else {
+				resp += `"error":{"code":-100,"message":"Unknown transaction"}}`
+			}
+		default:
+			t.Fatalf("unexpected request: %s", r.Method)
+		}
+		requestHandler(t, w, resp)
+	}))
+	defer srv.Close()
+	c, err := New(context.TODO(), srv.URL, Options{Network: netmode.UnitTestNet})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		found.Store(false)
+		time.AfterFunc(30*time.Millisecond, func() { found.Store(true) })
+		log, err := c.WaitForTransaction(context.Background(), hash, 20)
+		require.NoError(t, err)
+		require.Equal(t, hash, log.TxHash)
+	})
+	t.Run("context", func(t *testing.T) {
+		found.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := c.WaitForTransaction(ctx, hash, 20)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("expired", func(t *testing.T) {
+		found.Store(false)
+		time.AfterFunc(30*time.Millisecond, func() { height.Store(21) })
+		_, err := c.WaitForTransaction(context.Background(), hash, 20)
+		require.True(t, errors.Is(err, ErrTxNotAccepted))
+	})
+	t.Run("accepted", func(t *testing.T) {
+		found.Store(true)
+		log, err := c.WaitForTransaction(context.Background(), hash, 20)
+		require.NoError(t, err)
+		require.Equal(t, hash, log.TxHash)
+	})
+}
+
+func TestWSWaitForTransaction(t *testing.T) {
+	defer setWaitPollIntervals(time.Hour)()
+
+	srv := newWSTestServer(t)
+	defer srv.Close()
+	checkRequest := func(t *testing.T, expected string) {
+		select {
+		case r := <-srv.requests:
+			require.Equal(t, expected, r)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for request")
+		}
+	}
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{Network: netmode.UnitTestNet})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	hash := util.Uint256{1, 2, 3}
+	sendExecution := func(t *testing.T, h util.Uint256) {
+		data, err := json.Marshal(result.ApplicationLog{TxHash: h, Trigger: "Application", VMState: "HALT", Stack: []stackitem.Item{}})
+		require.NoError(t, err)
+		require.NoError(t, srv.send(srv.lastConn(), `{"jsonrpc":"2.0","method":"transaction_executed","params":[`+string(data)+`]}`))
+	}
+
+	type waitResult struct {
+		log *result.ApplicationLog
+		err error
+	}
+	res := make(chan waitResult, 1)
+	go func() {
+		log, err := wsc.WaitForTransaction(context.Background(), hash, 20)
+		res <- waitResult{log, err}
+	}()
+	checkRequest(t, `subscribe["transaction_executed"]`)
+	checkRequest(t, `getapplicationlog["`+hash.StringLE()+`"]`)
+	checkRequest(t, `getblockcount[]`)
+
+	// Other transaction executions are not sent to Notifications.
+	sendExecution(t, util.Uint256{3, 2, 1})
+	sendExecution(t, hash)
+	select {
+	case r := <-res:
+		require.NoError(t, r.err)
+		require.Equal(t, hash, r.log.TxHash)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for transaction")
+	}
+	checkRequest(t, `unsubscribe["0"]`)
+	select {
+	case n := <-wsc.Notifications:
+		t.Fatalf("unexpected notification: %v", n)
+	default:
+	}
+
+	t.Run("user subscription", func(t *testing.T) {
+		state := "HALT"
+		_, err := wsc.SubscribeForTransactionExecutions(&state)
+		require.NoError(t, err)
+		checkRequest(t, `subscribe["transaction_executed",{"state":"HALT"}]`)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			_, err := wsc.WaitForTransaction(ctx, hash, 0)
+			res <- waitResult{nil, err}
+		}()
+		checkRequest(t, `subscribe["transaction_executed"]`)
+		checkRequest(t, `getapplicationlog["`+hash.StringLE()+`"]`)
+
+		// Matching user's filter.
+		sendExecution(t, util.Uint256{3, 2, 1})
+		select {
+		case n := <-wsc.Notifications:
+			require.Equal(t, util.Uint256{3, 2, 1}, n.Value.(*result.ApplicationLog).TxHash)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for notification")
+		}
+		cancel()
+		select {
+		case r := <-res:
+			require.True(t, errors.Is(r.err, context.Canceled))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for cancellation")
+		}
+		checkRequest(t, `unsubscribe["1"]`)
+	})
+}
+
+func TestExecutionFilterMatches(t *testing.T) {
+	contract := util.Uint160{1, 2, 3}
+	log := &result.ApplicationLog{
+		VMState: "HALT",
+		Events:  []result.NotificationEvent{{Contract: contract}},
+	}
+	require.True(t, executionFilterMatches(request.ExecutionFilter{}, log))
+	require.True(t, executionFilterMatches(request.ExecutionFilter{State: "HALT"}, log))
+	require.False(t, executionFilterMatches(request.ExecutionFilter{State: "FAULT"}, log))
+	require.True(t, executionFilterMatches(request.ExecutionFilter{State: "HALT", Contract: &contract}, log))
+	require.False(t, executionFilterMatches(request.ExecutionFilter{Contract: &util.Uint160{3, 2, 1}}, log))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -13,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/atomic"
 )
 
 // WSClient is a websocket-enabled RPC client that can be used with appropriate
@@ -26,25 +29,112 @@ type WSClient struct {
 	// it wants to use subscription mechanism, failing to do so will cause
 	// WSClient to block even regular requests. This channel is not buffered.
 	// In case of protocol error or upon connection closure this channel will
-	// be closed, so make sure to handle this.
+	// be closed (unless reconnection is enabled via Options.Reconnect), so
+	// make sure to handle this.
 	Notifications chan Notification
 
-	ws            *websocket.Conn
-	done          chan struct{}
-	responses     chan *response.Raw
-	requests      chan *request.Raw
-	shutdown      chan struct{}
-	subscriptions map[string]bool
+	done     chan struct{}
+	shutdown chan struct{}
+
+	connLock sync.RWMutex
+	conn     *wsConn
+
+	// reqLock serializes requests, as responses are not matched by ID.
+	reqLock sync.Mutex
+
+	subsLock sync.Mutex
+	// subscriptions contains active subscriptions by client-side IDs
+	// returned to the user, they're not reused and don't change after
+	// reconnection (unlike server-side ones).
+	subscriptions map[string]*wsSubscription
+	// lastSubID is the last client-side subscription ID given.
+	lastSubID uint64
+
+	// lastBlock is the index of the latest block known to the client.
+	lastBlock atomic.Uint32
+
+	// execSubLock serializes management of the internal execution
+	// subscription used by WaitForTransaction.
+	execSubLock sync.Mutex
+	// waitersLock protects execWaiters and execSubID.
+	waitersLock sync.Mutex
+	// execWaiters are channels of WaitForTransaction calls.
+	execWaiters map[util.Uint256][]chan *result.ApplicationLog
+	// execSubID is the server ID of the internal execution subscription,
+	// it's empty if there is none.
+	execSubID string
+}
+
+// wsConn is a single websocket connection of WSClient, it's replaced with
+// a new one upon reconnection.
+type wsConn struct {
+	ws        *websocket.Conn
+	done      chan struct{}
+	responses chan *response.Raw
+	requests  chan *request.Raw
+}
+
+// wsSubscription is an active WSClient subscription.
+type wsSubscription struct {
+	// serverID is the subscription ID used by the server, it's empty while
+	// the subscription is being restored after reconnection.
+	serverID string
+	// params are subscription parameters (without `from` replay index)
+	// used to restore it after reconnection.
+	params request.RawParams
+}
+
+// ReconnectOptions enables and configures automatic WSClient reconnection.
+// When the connection is lost WSClient tries to establish a new one with
+// exponential backoff, then it sends ReconnectedEventID notification and
+// restores all active subscriptions (with the same filters, but without
+// replaying old events), those that can't be restored are reported with
+// ResubscribeFailedEventID notifications. Requests made while there is no
+// connection fail.
+type ReconnectOptions struct {
+	// MinDelay is a delay before the first reconnection attempt, it's
+	// doubled after each failed attempt. One second is used by default.
+	MinDelay time.Duration
+	// MaxDelay is the maximum delay between attempts, one minute is used
+	// by default.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of consecutive failed attempts after which
+	// WSClient gives up and closes Notifications channel, zero means no
+	// limit.
+	MaxAttempts int
 }
 
 // Notification represents server-generated notification for client subscriptions.
-// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent
-// or transaction.Transaction based on Type.
+// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent,
+// result.MempoolEvent or transaction.Transaction based on Type. Client-generated
+// ReconnectedEventID notifications have ReconnectEvent value and
+// ResubscribeFailedEventID ones have ResubscribeFailedEvent value.
 type Notification struct {
 	Type  response.EventID
 	Value interface{}
 }
 
+// ReconnectEvent is sent to Notifications channel after successful
+// reconnection.
+type ReconnectEvent struct {
+	// LastBlockIndex is the index of the latest block known to the client
+	// before connection loss: either the last one received via
+	// `block_added` notification or the chain's height at the moment when
+	// connection was established. Events for subsequent blocks might have
+	// been missed.
+	LastBlockIndex uint32
+}
+
+// ResubscribeFailedEvent is sent to Notifications channel after ReconnectEvent
+// for every subscription that the server refused to restore, this
+// subscription is removed and will never receive events again.
+type ResubscribeFailedEvent struct {
+	// ID is the subscription ID returned to the user.
+	ID string
+	// Err is the error returned by the server.
+	Err error
+}
+
 // requestResponse is a combined type for request and response since we can get
 // any of them here.
 type requestResponse struct {
@@ -65,8 +155,14 @@ const (
 
 	// Write deadline.
 	wsWriteLimit = wsPingPeriod / 2
+
+	// Default reconnection delays.
+	defaultReconnectMinDelay = time.Second
+	defaultReconnectMaxDelay = time.Minute
 )
 
+var errConnLost = errors.New("connection lost")
+
 // NewWS returns a new WSClient ready to use (with established websocket
 // connection). You need to use websocket URL for it like `ws://1.2.3.4/ws`.
 func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error) {
@@ -77,8 +173,7 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 
 	cl.cli = nil
 
-	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, _, err := dialer.Dial(endpoint, nil)
+	ws, err := cl.dialWS()
 	if err != nil {
 		return nil, err
 	}
@@ -86,38 +181,215 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 		Client:        *cl,
 		Notifications: make(chan Notification),
 
-		ws:            ws,
 		shutdown:      make(chan struct{}),
 		done:          make(chan struct{}),
-		responses:     make(chan *response.Raw),
-		requests:      make(chan *request.Raw),
-		subscriptions: make(map[string]bool),
+		subscriptions: make(map[string]*wsSubscription),
+		execWaiters:   make(map[util.Uint256][]chan *result.ApplicationLog),
 	}
-	go wsc.wsReader()
-	go wsc.wsWriter()
 	wsc.requestF = wsc.makeWsRequest
+	wsc.batchF = wsc.makeWsBatchRequest
+	go wsc.wsSupervisor(wsc.startConn(ws))
+	if opts.Reconnect != nil {
+		wsc.updateLastBlock()
+	}
 	return wsc, nil
 }
 
+func (c *Client) dialWS() (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: c.opts.DialTimeout}
+	ws, _, err := dialer.Dial(c.endpoint.String(), c.opts.authHeader())
+	return ws, err
+}
+
+// startConn starts reader and writer routines for the given connection and
+// makes it the current one.
+func (c *WSClient) startConn(ws *websocket.Conn) *wsConn {
+	conn := &wsConn{
+		ws:        ws,
+		done:      make(chan struct{}),
+		responses: make(chan *response.Raw),
+		requests:  make(chan *request.Raw),
+	}
+	c.connLock.Lock()
+	c.conn = conn
+	c.connLock.Unlock()
+	go c.wsReader(conn)
+	go c.wsWriter(conn)
+	return conn
+}
+
+func (c *WSClient) getConn() *wsConn {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.conn
+}
+
 // Close closes connection to the remote side rendering this client instance
 // unusable.
 func (c *WSClient) Close() {
 	// Closing shutdown channel send signal to wsWriter to break out of the
 	// loop. In doing so it does ws.Close() closing the network connection
 	// which in turn makes wsReader receieve err from ws,ReadJSON() and also
-	// break out of the loop closing c.done channel in its shutdown sequence.
+	// break out of the loop closing connection's done channel, then
+	// wsSupervisor finishes closing c.done.
 	close(c.shutdown)
 	<-c.done
 }
 
-func (c *WSClient) wsReader() {
-	c.ws.SetReadLimit(wsReadLimit)
-	c.ws.SetPongHandler(func(string) error { c.ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
+// wsSupervisor waits for the connection to break and then either reconnects
+// or shuts the client down.
+func (c *WSClient) wsSupervisor(conn *wsConn) {
+	for conn != nil {
+		<-conn.done
+		conn = c.reconnect()
+	}
+	close(c.done)
+	close(c.Notifications)
+}
+
+// reconnect establishes a new connection and restores client's state, it
+// returns nil if reconnection is disabled, the client is shutting down or
+// the number of attempts is exceeded.
+func (c *WSClient) reconnect() *wsConn {
+	opts := c.opts.Reconnect
+	if opts == nil || isClosed(c.shutdown) {
+		return nil
+	}
+	delay, maxDelay := opts.MinDelay, opts.MaxDelay
+	if delay <= 0 {
+		delay = defaultReconnectMinDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultReconnectMaxDelay
+	}
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-c.shutdown:
+			return nil
+		case <-time.After(delay):
+		}
+		ws, err := c.dialWS()
+		if err == nil {
+			conn := c.startConn(ws)
+			if c.restore(conn) {
+				return conn
+			}
+		}
+		if opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts {
+			return nil
+		}
+		if delay *= 2; delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// restore notifies the user about reconnection and restores subscriptions
+// using the new connection. It returns false if the connection is lost in
+// the process.
+func (c *WSClient) restore(conn *wsConn) bool {
+	c.subsLock.Lock()
+	ids := make([]string, 0, len(c.subscriptions))
+	for id, sub := range c.subscriptions {
+		// Server-side subscriptions are lost with the connection.
+		sub.serverID = ""
+		ids = append(ids, id)
+	}
+	c.subsLock.Unlock()
+
+	select {
+	case <-c.shutdown:
+		return true // Will be handled by wsSupervisor.
+	case <-conn.done:
+		return false
+	case c.Notifications <- Notification{
+		Type:  response.ReconnectedEventID,
+		Value: &ReconnectEvent{LastBlockIndex: c.lastBlock.Load()},
+	}:
+	}
+
+	for _, id := range ids {
+		c.subsLock.Lock()
+		sub, ok := c.subscriptions[id]
+		c.subsLock.Unlock()
+		if !ok {
+			continue // Unsubscribed in the meantime.
+		}
+		var serverID string
+		err := c.performRequest("subscribe", sub.params, &serverID)
+		if err != nil && isClosed(conn.done) {
+			return false
+		}
+		c.subsLock.Lock()
+		_, ok = c.subscriptions[id]
+		switch {
+		case err != nil:
+			// The server doesn't accept this subscription anymore.
+			delete(c.subscriptions, id)
+		case ok:
+			sub.serverID = serverID
+		}
+		c.subsLock.Unlock()
+		if err != nil {
+			select {
+			case <-c.shutdown:
+				return true
+			case <-conn.done:
+				return false
+			case c.Notifications <- Notification{
+				Type:  response.ResubscribeFailedEventID,
+				Value: &ResubscribeFailedEvent{ID: id, Err: err},
+			}:
+			}
+		} else if !ok {
+			// Unsubscribed while being restored.
+			var resp bool
+			_ = c.performRequest("unsubscribe", request.NewRawParams(serverID), &resp)
+		}
+	}
+	if !c.restoreExecSubscription(conn) {
+		return false
+	}
+	c.updateLastBlock()
+	return true
+}
+
+// updateLastBlock sets the last known block to the current chain's height if
+// it's higher.
+func (c *WSClient) updateLastBlock() {
+	count, err := c.GetBlockCount()
+	if err == nil && count > 0 {
+		c.seenBlock(count - 1)
+	}
+}
+
+func (c *WSClient) seenBlock(index uint32) {
+	for {
+		last := c.lastBlock.Load()
+		if index <= last || c.lastBlock.CAS(last, index) {
+			return
+		}
+	}
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *WSClient) wsReader(conn *wsConn) {
+	ws := conn.ws
+	ws.SetReadLimit(wsReadLimit)
+	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
 readloop:
 	for {
 		rr := new(requestResponse)
-		c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
-		err := c.ws.ReadJSON(rr)
+		ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		err := ws.ReadJSON(rr)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
 			break
@@ -144,6 +416,8 @@ readloop:
 				val = new(result.NotificationEvent)
 			case response.ExecutionEventID:
 				val = new(result.ApplicationLog)
+			case response.MempoolTransactionAddedEventID, response.MempoolTransactionRemovedEventID:
+				val = &result.MempoolEvent{Transaction: &transaction.Transaction{Network: c.opts.Network}}
 			case response.MissedEventID:
 				// No value.
 			default:
@@ -157,6 +431,12 @@ readloop:
 					break
 				}
 			}
+			if b, ok := val.(*block.Block); ok {
+				c.seenBlock(b.Index)
+			}
+			if l, ok := val.(*result.ApplicationLog); ok && !c.notifyExecWaiters(l) {
+				continue
+			}
 			c.Notifications <- Notification{event, val}
 		} else if rr.RawID != nil && (rr.Error != nil || rr.Result != nil) {
 			resp := new(response.Raw)
@@ -164,38 +444,33 @@ readloop:
 			resp.JSONRPC = rr.JSONRPC
 			resp.Error = rr.Error
 			resp.Result = rr.Result
-			c.responses <- resp
+			conn.responses <- resp
 		} else {
 			// Malformed response, neither valid request, nor valid response.
 			break
 		}
 	}
-	close(c.done)
-	close(c.responses)
-	close(c.Notifications)
+	close(conn.done)
 }
 
-func (c *WSClient) wsWriter() {
+func (c *WSClient) wsWriter(conn *wsConn) {
 	pingTicker := time.NewTicker(wsPingPeriod)
-	defer c.ws.Close()
+	defer conn.ws.Close()
 	defer pingTicker.Stop()
 	for {
 		select {
 		case <-c.shutdown:
 			return
-		case <-c.done:
+		case <-conn.done:
 			return
-		case req, ok := <-c.requests:
-			if !ok {
-				return
-			}
-			c.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout))
-			if err := c.ws.WriteJSON(req); err != nil {
+		case req := <-conn.requests:
+			conn.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout))
+			if err := conn.ws.WriteJSON(req); err != nil {
 				return
 			}
 		case <-pingTicker.C:
-			c.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit))
-			if err := c.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+			conn.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit))
+			if err := conn.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				return
 			}
 		}
@@ -204,42 +479,76 @@ func (c *WSClient) wsWriter() {
 }
 
 func (c *WSClient) makeWsRequest(r *request.Raw) (*response.Raw, error) {
+	c.reqLock.Lock()
+	defer c.reqLock.Unlock()
+
+	conn := c.getConn()
 	select {
-	case <-c.done:
-		return nil, errors.New("connection lost")
-	case c.requests <- r:
+	case <-conn.done:
+		return nil, errConnLost
+	case conn.requests <- r:
 	}
 	select {
-	case <-c.done:
-		return nil, errors.New("connection lost")
-	case resp := <-c.responses:
+	case <-conn.done:
+		return nil, errConnLost
+	case resp := <-conn.responses:
 		return resp, nil
 	}
 }
 
+// makeWsBatchRequest sends batched requests one by one, there is no need to
+// group them into a real batch as the connection is persistent anyway.
+func (c *WSClient) makeWsBatchRequest(rs []*request.Raw) (response.Batch, error) {
+	var resps = make(response.Batch, 0, len(rs))
+
+	for _, r := range rs {
+		resp, err := c.makeWsRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		resps = append(resps, *resp)
+	}
+	return resps, nil
+}
+
 func (c *WSClient) performSubscription(params request.RawParams) (string, error) {
 	var resp string
 
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
-	c.subscriptions[resp] = true
-	return resp, nil
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+	c.lastSubID++
+	id := strconv.FormatUint(c.lastSubID, 10)
+	c.subscriptions[id] = &wsSubscription{serverID: resp, params: withoutFromParam(params)}
+	return id, nil
 }
 
 func (c *WSClient) performUnsubscription(id string) error {
 	var resp bool
 
-	if !c.subscriptions[id] {
+	c.subsLock.Lock()
+	sub, ok := c.subscriptions[id]
+	if !ok {
+		c.subsLock.Unlock()
 		return errors.New("no subscription with this ID")
 	}
-	if err := c.performRequest("unsubscribe", request.NewRawParams(id), &resp); err != nil {
-		return err
-	}
-	if !resp {
-		return errors.New("unsubscribe method returned false result")
+	serverID := sub.serverID
+	c.subsLock.Unlock()
+	// There is no server-side subscription to remove if it's being
+	// restored, restore takes care of it.
+	if serverID != "" {
+		if err := c.performRequest("unsubscribe", request.NewRawParams(serverID), &resp); err != nil {
+			return err
+		}
+		if !resp {
+			return errors.New("unsubscribe method returned false result")
+		}
 	}
+	c.subsLock.Lock()
 	delete(c.subscriptions, id)
+	c.subsLock.Unlock()
 	return nil
 }
 
@@ -247,22 +556,69 @@ func (c *WSClient) performUnsubscription(id string) error {
 // of client. It can filtered by primary consensus node index, nil value doesn't
 // add any filters.
 func (c *WSClient) SubscribeForNewBlocks(primary *int) (string, error) {
+	return c.performSubscription(newBlocksParams(primary))
+}
+
+// SubscribeForNewBlocksFrom is the same as SubscribeForNewBlocks, but the
+// server also sends events for blocks starting from the given index that are
+// already in the chain before switching to new ones.
+func (c *WSClient) SubscribeForNewBlocksFrom(primary *int, from uint32) (string, error) {
+	return c.performSubscription(withFromParam(newBlocksParams(primary), from))
+}
+
+// SubscribeForNewBlocksWithFilters adds subscription for new block events
+// matching any of the given filters (no filtering is done if the list is
+// empty). If from is not nil, events for blocks starting from this index that
+// are already in the chain are sent first.
+func (c *WSClient) SubscribeForNewBlocksWithFilters(filters []request.BlockFilter, from *uint32) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("block_added", alts, from))
+}
+
+func newBlocksParams(primary *int) request.RawParams {
 	params := request.NewRawParams("block_added")
 	if primary != nil {
 		params.Values = append(params.Values, request.BlockFilter{Primary: *primary})
 	}
-	return c.performSubscription(params)
+	return params
 }
 
 // SubscribeForNewTransactions adds subscription for new transaction events to
 // this instance of client. It can be filtered by sender and/or signer, nil
 // value is treated as missing filter.
 func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, signer *util.Uint160) (string, error) {
+	return c.performSubscription(newTransactionsParams(sender, signer))
+}
+
+// SubscribeForNewTransactionsFrom is the same as SubscribeForNewTransactions,
+// but the server also sends events for transactions from blocks starting
+// from the given index that are already in the chain.
+func (c *WSClient) SubscribeForNewTransactionsFrom(sender *util.Uint160, signer *util.Uint160, from uint32) (string, error) {
+	return c.performSubscription(withFromParam(newTransactionsParams(sender, signer), from))
+}
+
+// SubscribeForNewTransactionsWithFilters adds subscription for new
+// transaction events matching any of the given filters (no filtering is done
+// if the list is empty). If from is not nil, events for transactions from
+// blocks starting from this index that are already in the chain are sent
+// first.
+func (c *WSClient) SubscribeForNewTransactionsWithFilters(filters []request.TxFilter, from *uint32) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("transaction_added", alts, from))
+}
+
+func newTransactionsParams(sender *util.Uint160, signer *util.Uint160) request.RawParams {
 	params := request.NewRawParams("transaction_added")
 	if sender != nil || signer != nil {
 		params.Values = append(params.Values, request.TxFilter{Sender: sender, Signer: signer})
 	}
-	return c.performSubscription(params)
+	return params
 }
 
 // SubscribeForExecutionNotifications adds subscription for notifications
@@ -270,11 +626,35 @@ func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, signer *uti
 // filtered by contract's hash (that emits notifications), nil value puts no such
 // restrictions.
 func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, name *string) (string, error) {
+	return c.performSubscription(newNotificationsParams(contract, name))
+}
+
+// SubscribeForExecutionNotificationsFrom is the same as
+// SubscribeForExecutionNotifications, but the server also sends
+// notifications generated by blocks starting from the given index that are
+// already in the chain.
+func (c *WSClient) SubscribeForExecutionNotificationsFrom(contract *util.Uint160, name *string, from uint32) (string, error) {
+	return c.performSubscription(withFromParam(newNotificationsParams(contract, name), from))
+}
+
+// SubscribeForExecutionNotificationsWithFilters adds subscription for
+// notifications matching any of the given filters (no filtering is done if
+// the list is empty). If from is not nil, notifications generated by blocks
+// starting from this index that are already in the chain are sent first.
+func (c *WSClient) SubscribeForExecutionNotificationsWithFilters(filters []request.NotificationFilter, from *uint32) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("notification_from_execution", alts, from))
+}
+
+func newNotificationsParams(contract *util.Uint160, name *string) request.RawParams {
 	params := request.NewRawParams("notification_from_execution")
 	if contract != nil || name != nil {
 		params.Values = append(params.Values, request.NotificationFilter{Contract: contract, Name: name})
 	}
-	return c.performSubscription(params)
+	return params
 }
 
 // SubscribeForTransactionExecutions adds subscription for application execution
@@ -282,14 +662,115 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, na
 // be filtered by state (HALT/FAULT) to check for successful or failing
 // transactions, nil value means no filtering.
 func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, error) {
+	params, err := newExecutionsParams(state)
+	if err != nil {
+		return "", err
+	}
+	return c.performSubscription(params)
+}
+
+// SubscribeForTransactionExecutionsFrom is the same as
+// SubscribeForTransactionExecutions, but the server also sends execution
+// results of blocks starting from the given index that are already in the
+// chain.
+func (c *WSClient) SubscribeForTransactionExecutionsFrom(state *string, from uint32) (string, error) {
+	params, err := newExecutionsParams(state)
+	if err != nil {
+		return "", err
+	}
+	return c.performSubscription(withFromParam(params, from))
+}
+
+// SubscribeForTransactionExecutionsWithFilters adds subscription for
+// application execution results matching any of the given filters (no
+// filtering is done if the list is empty). If from is not nil, execution
+// results of blocks starting from this index that are already in the chain
+// are sent first.
+func (c *WSClient) SubscribeForTransactionExecutionsWithFilters(filters []request.ExecutionFilter, from *uint32) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		if filters[i].State != "" && filters[i].State != "HALT" && filters[i].State != "FAULT" {
+			return "", errors.New("bad state parameter")
+		}
+		if filters[i].State == "" && filters[i].Contract == nil {
+			return "", errors.New("empty execution filter")
+		}
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("transaction_executed", alts, from))
+}
+
+func newExecutionsParams(state *string) (request.RawParams, error) {
 	params := request.NewRawParams("transaction_executed")
 	if state != nil {
 		if *state != "HALT" && *state != "FAULT" {
-			return "", errors.New("bad state parameter")
+			return params, errors.New("bad state parameter")
 		}
 		params.Values = append(params.Values, request.ExecutionFilter{State: *state})
 	}
-	return c.performSubscription(params)
+	return params, nil
+}
+
+// SubscribeForMempoolTransactionsAdded adds subscription for transactions
+// added to the server's memory pool. Events can be filtered by transaction
+// sender, signer and its scopes (event is sent if it matches any of the given
+// filters), empty list means no filtering.
+func (c *WSClient) SubscribeForMempoolTransactionsAdded(filters []request.TxFilter) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("mempool_transaction_added", alts, nil))
+}
+
+// SubscribeForMempoolTransactionsRemoved adds subscription for transactions
+// removed from the server's memory pool (events contain removal reason). It
+// can be filtered the same way as SubscribeForMempoolTransactionsAdded.
+func (c *WSClient) SubscribeForMempoolTransactionsRemoved(filters []request.TxFilter) (string, error) {
+	alts := make([]interface{}, len(filters))
+	for i := range filters {
+		alts[i] = filters[i]
+	}
+	return c.performSubscription(newFilteredParams("mempool_transaction_removed", alts, nil))
+}
+
+// newFilteredParams creates subscription parameters for the given stream with
+// a single filter or a list of alternative filters.
+func newFilteredParams(event string, filters []interface{}, from *uint32) request.RawParams {
+	params := request.NewRawParams(event)
+	switch len(filters) {
+	case 0:
+	case 1:
+		params.Values = append(params.Values, filters[0])
+	default:
+		params.Values = append(params.Values, filters)
+	}
+	if from != nil {
+		params = withFromParam(params, *from)
+	}
+	return params
+}
+
+// withoutFromParam strips replay block index from subscription parameters.
+func withoutFromParam(params request.RawParams) request.RawParams {
+	if len(params.Values) < 3 {
+		return params
+	}
+	values := params.Values[:2]
+	if values[1] == nil {
+		values = values[:1]
+	}
+	return request.RawParams{Values: values}
+}
+
+// withFromParam adds block index to replay events from to subscription
+// parameters (using null filter if there is none).
+func withFromParam(params request.RawParams, from uint32) request.RawParams {
+	if len(params.Values) == 1 {
+		params.Values = append(params.Values, nil)
+	}
+	params.Values = append(params.Values, from)
+	return params
 }
 
 // Unsubscribe removes subscription for given event stream.
@@ -299,7 +780,13 @@ func (c *WSClient) Unsubscribe(id string) error {
 
 // UnsubscribeAll removes all active subscriptions of current client.
 func (c *WSClient) UnsubscribeAll() error {
+	c.subsLock.Lock()
+	ids := make([]string, 0, len(c.subscriptions))
 	for id := range c.subscriptions {
+		ids = append(ids, id)
+	}
+	c.subsLock.Unlock()
+	for _, id := range ids {
 		err := c.performUnsubscription(id)
 		if err != nil {
 			return err
@@ -2,15 +2,22 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +44,36 @@ func TestWSClientSubscription(t *testing.T) {
 		"executions": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForTransactionExecutions(nil)
 		},
+		"blocks from": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNewBlocksFrom(nil, 10)
+		},
+		"transactions from": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNewTransactionsFrom(nil, nil, 10)
+		},
+		"notifications from": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForExecutionNotificationsFrom(nil, nil, 10)
+		},
+		"executions from": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForTransactionExecutionsFrom(nil, 10)
+		},
+		"blocks with filters": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNewBlocksWithFilters(nil, nil)
+		},
+		"transactions with filters": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNewTransactionsWithFilters(nil, nil)
+		},
+		"notifications with filters": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForExecutionNotificationsWithFilters(nil, nil)
+		},
+		"executions with filters": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForTransactionExecutionsWithFilters(nil, nil)
+		},
+		"mempool added": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForMempoolTransactionsAdded(nil)
+		},
+		"mempool removed": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForMempoolTransactionsRemoved([]request.TxFilter{{}})
+		},
 	}
 	t.Run("good", func(t *testing.T) {
 		for name, f := range cases {
@@ -47,7 +84,8 @@ func TestWSClientSubscription(t *testing.T) {
 				require.NoError(t, err)
 				id, err := f(wsc)
 				require.NoError(t, err)
-				require.Equal(t, "55aaff00", id)
+				require.Equal(t, "1", id)
+				require.Equal(t, "55aaff00", wsc.subscriptions[id].serverID)
 			})
 		}
 	})
@@ -73,13 +111,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 	var cases = map[string]responseCheck{
 		"good": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.NoError(t, err)
 		}},
 		"all": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.UnsubscribeAll()
 			require.NoError(t, err)
 			require.Equal(t, 0, len(wsc.subscriptions))
@@ -90,13 +128,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 		}},
 		"error returned": {`{"jsonrpc": "2.0", "id": 1, "error":{"code":-32602,"message":"Invalid Params"}}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
 		"false returned": {`{"jsonrpc": "2.0", "id": 1, "result": false}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
@@ -120,6 +158,8 @@ func TestWSClientEvents(t *testing.T) {
 		`{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"dpFiJB7t+XwkgWUq3xug9b9XQxs="},{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"Integer","value":"1000"}]}]}}]}`,
 		`{"jsonrpc":"2.0","method":"transaction_executed","params":[{"txid":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","trigger":"Application","vmstate":"HALT","gasconsumed":"6042610","stack":[],"notifications":[{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}]}},{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"transfer","state":{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}}]}]}`,
 		`{"jsonrpc":"2.0","method":"block_added","params":[{"size":1641,"nextblockhash":"0x003abea54aa3c5edba7e33fb7ca96452cb65ff8cd36ce1cdfd412a6c4d3ea38a","confirmations":6,"hash":"0xd9518e322440714b0564d6f84a9a39b527b5480e4e7f7932895777a4c8fa0a9e","version":0,"previousblockhash":"0xa496577895eb8c227bb866dc44f99f21c0cf06417ca8f2a877cc5d761a50dac0","merkleroot":"0x2b0f84636d814f3a952de145c8f4028f5664132f2719f5902e1884c9fba59806","time":1596101407001,"index":1,"nextconsensus":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","witnesses":[{"invocation":"DEANAGtuw7+VLVNvmpESGL4+xqlKgBSIWmMBEtABi86ixft2Q7AcaOC89M+yKVIuTel9doVJcCvfx93CcQ63DZqCDEBtwUEkjuzP9h8ZTL0GEKfGr01pazmh8s2TswJge5sAGryYE/+kjw5NCLFmowhPU73qUYQ9jq1zMNMXF+Deqxp/DEDkytkkwJec5n4x2+l5zsZHT6QTXJsByZOWXaGPVJKK8CeDccZba7Mf4MdSkWqSt61xUtlgM2Iqhe/Iuokf/ZEXDEAOH72S12CuAxVu0XNGyj3cgMtad+Bghxvr16T9+ELaWkpR4ko26FdStYC2XiCkzanXTtAD1Id5rREsxfFeKb83","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBE43vrw=="}],"consensusdata":{"primary":0,"nonce":"0000000000000457"},"tx":[{"hash":"0x32f9bd3a2707475407c41bf5daacf9560e25ed74f6d85b3afb2ef72edb2325ba","size":555,"version":0,"nonce":2,"sender":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","sysfee":"10000000","netfee":"4488350","validuntilblock":1200,"attributes":[],"signers":[{"account":"0x95307cb9cc8c4578cef9f6845895eb7aa8be125e","scopes":"CalledByEntry"}],"script":"Ahjd9QUMFKqKz4WdT+QCs05nPyFWgheWpIjrDBReEr6oeuuVWIT2+c54RYzMuXwwlRPADAh0cmFuc2ZlcgwUJQWey0h406h1+RxRzt7TMNRXX95BYn1bUjg=","witnesses":[{"invocation":"DEAIcSUsAtRql4t+IEeo+p4+YI7bA6PG+1xxUkPIb2vNlaMl4PumjQVFT+bg2ldxCYa6zccoc4n0Gfryi82EhGpGDECR4fQDr4njo94mF6/GA+OH0Y5k735yGMEZHs96586BRp6f0AQxfmIPvLcS4Yero9p0zgVl9BDg3TxU5piRylR5DEAcjOT7JjEwNRnKgDDkXfh63Yc3MorMbdb2asTiDu0aexy5M5XcikA1jypJT4wkhxjp0rrgFZRSzeYhwV0Klz+yDECIopKxLd4p+hLHxFq07WffXd++sN0WIRWzvMJncCrJqSP8zz65r8TGFFzvZMdGelWKO7KhBOhIK6wryuWNlaDI","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBE43vrw=="}]},{"hash":"0xd35d6386ec2f29b90839536f6af9466098d1665e951cdd0a20db6b4629b08369","size":559,"version":0,"nonce":3,"sender":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","sysfee":"10000000","netfee":"4492350","validuntilblock":1200,"attributes":[],"signers":[{"account":"0x95307cb9cc8c4578cef9f6845895eb7aa8be125e","scopes":"CalledByEntry"}],"script":"AwDodkgXAAAADBSqis+FnU/kArNOZz8hVoIXlqSI6wwUXhK+qHrrlViE9vnOeEWMzLl8MJUTwAwIdHJhbnNmZXIMFLyvQdaEx9StbuDZnalwe50fDI5mQWJ9W1I4","witnesses":[{"invocation":"DECKUPl9d502XPI564EC2BroqpN274uV3n1z6kCBCmbS715lzmPbh24LESMsAP2TFohhdhm16aDfNsPi5tkB/FE4DEDzJFts9VYc1lIivGAZZSxACzAV/96Kn2WAaS3bDIlAJHCShsfz+Rn3NuvMyutujYM4vyEipAX9gkjcvFWGKRObDECkI883onhG9aYTxwQWDxsmofuiooRJOic/cJ1H8nqUEvMqATYKgdHaBOJBVYsKq9M9oUv/fj6JFbMDrcasvpiaDECEqkq2b50aEc1NGM9DBAsYLEeZHrM1BwX3a2tBOeeD/KLtmTga1IZogsZgpis2BOToZO6LuN9FJYcn+/iGcC5u","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBE43vrw=="}]}]}]}`,
+		`{"jsonrpc":"2.0","method":"mempool_transaction_added","params":[{"transaction":{"hash":"0x32f9bd3a2707475407c41bf5daacf9560e25ed74f6d85b3afb2ef72edb2325ba","size":555,"version":0,"nonce":2,"sender":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","sysfee":"10000000","netfee":"4488350","validuntilblock":1200,"attributes":[],"signers":[{"account":"0x95307cb9cc8c4578cef9f6845895eb7aa8be125e","scopes":"CalledByEntry"}],"script":"Ahjd9QUMFKqKz4WdT+QCs05nPyFWgheWpIjrDBReEr6oeuuVWIT2+c54RYzMuXwwlRPADAh0cmFuc2ZlcgwUJQWey0h406h1+RxRzt7TMNRXX95BYn1bUjg=","witnesses":[{"invocation":"DEAIcSUsAtRql4t+IEeo+p4+YI7bA6PG+1xxUkPIb2vNlaMl4PumjQVFT+bg2ldxCYa6zccoc4n0Gfryi82EhGpGDECR4fQDr4njo94mF6/GA+OH0Y5k735yGMEZHs96586BRp6f0AQxfmIPvLcS4Yero9p0zgVl9BDg3TxU5piRylR5DEAcjOT7JjEwNRnKgDDkXfh63Yc3MorMbdb2asTiDu0aexy5M5XcikA1jypJT4wkhxjp0rrgFZRSzeYhwV0Klz+yDECIopKxLd4p+hLHxFq07WffXd++sN0WIRWzvMJncCrJqSP8zz65r8TGFFzvZMdGelWKO7KhBOhIK6wryuWNlaDI","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBE43vrw=="}]}}]}`,
+		`{"jsonrpc":"2.0","method":"mempool_transaction_removed","params":[{"transaction":{"hash":"0x32f9bd3a2707475407c41bf5daacf9560e25ed74f6d85b3afb2ef72edb2325ba","size":555,"version":0,"nonce":2,"sender":"NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY","sysfee":"10000000","netfee":"4488350","validuntilblock":1200,"attributes":[],"signers":[{"account":"0x95307cb9cc8c4578cef9f6845895eb7aa8be125e","scopes":"CalledByEntry"}],"script":"Ahjd9QUMFKqKz4WdT+QCs05nPyFWgheWpIjrDBReEr6oeuuVWIT2+c54RYzMuXwwlRPADAh0cmFuc2ZlcgwUJQWey0h406h1+RxRzt7TMNRXX95BYn1bUjg=","witnesses":[{"invocation":"DEAIcSUsAtRql4t+IEeo+p4+YI7bA6PG+1xxUkPIb2vNlaMl4PumjQVFT+bg2ldxCYa6zccoc4n0Gfryi82EhGpGDECR4fQDr4njo94mF6/GA+OH0Y5k735yGMEZHs96586BRp6f0AQxfmIPvLcS4Yero9p0zgVl9BDg3TxU5piRylR5DEAcjOT7JjEwNRnKgDDkXfh63Yc3MorMbdb2asTiDu0aexy5M5XcikA1jypJT4wkhxjp0rrgFZRSzeYhwV0Klz+yDECIopKxLd4p+hLHxFq07WffXd++sN0WIRWzvMJncCrJqSP8zz65r8TGFFzvZMdGelWKO7KhBOhIK6wryuWNlaDI","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBE43vrw=="}]},"reason":"included"}]}`,
 		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`,
 	}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -167,6 +207,10 @@ func TestWSExecutionVMStateCheck(t *testing.T) {
 	filter := "NONE"
 	_, err = wsc.SubscribeForTransactionExecutions(&filter)
 	require.Error(t, err)
+	_, err = wsc.SubscribeForTransactionExecutionsWithFilters([]request.ExecutionFilter{{State: filter}}, nil)
+	require.Error(t, err)
+	_, err = wsc.SubscribeForTransactionExecutionsWithFilters([]request.ExecutionFilter{{}}, nil)
+	require.Error(t, err)
 	wsc.Close()
 }
 
@@ -189,6 +233,7 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				filt, ok := param.Value.(request.BlockFilter)
 				require.Equal(t, true, ok)
 				require.Equal(t, 3, filt.Primary)
+				require.False(t, filt.AnyPrimary)
 			},
 		},
 		{"transactions sender",
@@ -304,6 +349,122 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "FAULT", filt.State)
 			},
 		},
+		{"blocks with transaction filter",
+			func(t *testing.T, wsc *WSClient) {
+				scopes := transaction.CalledByEntry
+				_, err := wsc.SubscribeForNewBlocksWithFilters([]request.BlockFilter{
+					{AnyPrimary: true, Transaction: &request.TxFilter{Scopes: &scopes}},
+				}, nil)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.BlockFilterT, param.Type)
+				filt, ok := param.Value.(request.BlockFilter)
+				require.Equal(t, true, ok)
+				require.True(t, filt.AnyPrimary)
+				require.Equal(t, transaction.CalledByEntry, *filt.Transaction.Scopes)
+			},
+		},
+		{"transactions signer with scopes",
+			func(t *testing.T, wsc *WSClient) {
+				signer := util.Uint160{0, 42}
+				scopes := transaction.Global
+				_, err := wsc.SubscribeForNewTransactionsWithFilters([]request.TxFilter{
+					{Signer: &signer, Scopes: &scopes},
+				}, nil)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{0, 42}, *filt.Signer)
+				require.Equal(t, transaction.Global, *filt.Scopes)
+			},
+		},
+		{"notifications alternatives",
+			func(t *testing.T, wsc *WSClient) {
+				contract := util.Uint160{1, 2, 3, 4, 5}
+				name := "my_pretty_notification"
+				_, err := wsc.SubscribeForExecutionNotificationsWithFilters([]request.NotificationFilter{
+					{Contract: &contract, Name: &name},
+					{Name: &name},
+				}, nil)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.ArrayT, param.Type)
+				alts, err := param.GetArray()
+				require.NoError(t, err)
+				require.Equal(t, 2, len(alts))
+				filt, ok := alts[0].Value.(request.NotificationFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, *filt.Contract)
+				require.Equal(t, "my_pretty_notification", *filt.Name)
+				filt, ok = alts[1].Value.(request.NotificationFilter)
+				require.Equal(t, true, ok)
+				require.Nil(t, filt.Contract)
+				require.Equal(t, "my_pretty_notification", *filt.Name)
+				require.Nil(t, p.Value(2))
+			},
+		},
+		{"executions contract from",
+			func(t *testing.T, wsc *WSClient) {
+				contract := util.Uint160{1, 2, 3, 4, 5}
+				from := uint32(42)
+				_, err := wsc.SubscribeForTransactionExecutionsWithFilters([]request.ExecutionFilter{
+					{State: "HALT", Contract: &contract},
+				}, &from)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.ExecutionFilterT, param.Type)
+				filt, ok := param.Value.(request.ExecutionFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, "HALT", filt.State)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, *filt.Contract)
+				from, err := p.Value(2).GetInt()
+				require.NoError(t, err)
+				require.Equal(t, 42, from)
+			},
+		},
+		{"blocks from",
+			func(t *testing.T, wsc *WSClient) {
+				_, err := wsc.SubscribeForNewBlocksFrom(nil, 42)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Nil(t, param.Value)
+				from, err := p.Value(2).GetInt()
+				require.NoError(t, err)
+				require.Equal(t, 42, from)
+			},
+		},
+		{"executions from",
+			func(t *testing.T, wsc *WSClient) {
+				state := "HALT"
+				_, err := wsc.SubscribeForTransactionExecutionsFrom(&state, 42)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.ExecutionFilterT, param.Type)
+				from, err := p.Value(2).GetInt()
+				require.NoError(t, err)
+				require.Equal(t, 42, from)
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -347,3 +508,271 @@ func TestNewWS(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// wsTestServer is a websocket server stub answering getblockcount,
+// getapplicationlog (with an error), subscribe and unsubscribe requests that
+// can drop its connections. Like the real server it gives the lowest free
+// subscription ID of the connection for every new subscription.
+type wsTestServer struct {
+	*httptest.Server
+
+	lock  sync.Mutex
+	conns []*websocket.Conn
+	// reject makes subscribe requests with parameters containing it fail.
+	reject string
+	// requests receives method and parameters of every request.
+	requests chan string
+}
+
+func newWSTestServer(t *testing.T) *wsTestServer {
+	s := &wsTestServer{requests: make(chan string, 16)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		s.lock.Lock()
+		s.conns = append(s.conns, ws)
+		s.lock.Unlock()
+		var subs []bool
+		for {
+			r := new(request.In)
+			if err := ws.ReadJSON(r); err != nil {
+				break
+			}
+			var res = `"result": `
+			switch r.Method {
+			case "getblockcount":
+				res += "10"
+			case "getapplicationlog":
+				res = `"error": {"code": -100, "message": "Unknown transaction"}`
+			case "subscribe":
+				s.lock.Lock()
+				reject := s.reject != "" && strings.Contains(string(r.RawParams), s.reject)
+				s.lock.Unlock()
+				if reject {
+					res = `"error": {"code": -32602, "message": "Invalid Params"}`
+					break
+				}
+				var id int
+				for id < len(subs) && subs[id] {
+					id++
+				}
+				if id == len(subs) {
+					subs = append(subs, false)
+				}
+				subs[id] = true
+				res += fmt.Sprintf(`"%d"`, id)
+			case "unsubscribe":
+				var ids []string
+				require.NoError(t, json.Unmarshal(r.RawParams, &ids))
+				id, err := strconv.Atoi(ids[0])
+				require.NoError(t, err)
+				ok := id < len(subs) && subs[id]
+				if ok {
+					subs[id] = false
+				}
+				res += strconv.FormatBool(ok)
+			}
+			s.requests <- r.Method + string(r.RawParams)
+			if s.send(ws, `{"jsonrpc": "2.0", "id": 1, `+res+`}`) != nil {
+				break
+			}
+		}
+		ws.Close()
+	}))
+	return s
+}
+
+func (s *wsTestServer) send(ws *websocket.Conn, msg string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return ws.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// lastConn returns the latest connection to the server.
+func (s *wsTestServer) lastConn() *websocket.Conn {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.conns[len(s.conns)-1]
+}
+
+func TestWSClientReconnect(t *testing.T) {
+	srv := newWSTestServer(t)
+	defer srv.Close()
+
+	checkRequest := func(t *testing.T, expected string) {
+		select {
+		case r := <-srv.requests:
+			require.Equal(t, expected, r)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for request")
+		}
+	}
+	getNotification := func(t *testing.T, wsc *WSClient) Notification {
+		select {
+		case n, ok := <-wsc.Notifications:
+			require.True(t, ok)
+			return n
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return Notification{}
+	}
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Network:   netmode.UnitTestNet,
+		Reconnect: &ReconnectOptions{MinDelay: 10 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	checkRequest(t, `getblockcount[]`)
+
+	primary := 0
+	id, err := wsc.SubscribeForNewBlocksFrom(&primary, 3)
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	checkRequest(t, `subscribe["block_added",{"primary":0},3]`)
+	// It's "1" for the server.
+	id2, err := wsc.SubscribeForNewBlocks(&primary)
+	require.NoError(t, err)
+	checkRequest(t, `subscribe["block_added",{"primary":0}]`)
+	require.NoError(t, wsc.Unsubscribe(id2))
+	checkRequest(t, `unsubscribe["1"]`)
+
+	b := block.New(netmode.UnitTestNet)
+	b.Index = 12
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	require.NoError(t, srv.send(srv.lastConn(), `{"jsonrpc":"2.0","method":"block_added","params":[`+string(data)+`]}`))
+	n := getNotification(t, wsc)
+	require.Equal(t, response.BlockEventID, n.Type)
+	require.Equal(t, uint32(12), n.Value.(*block.Block).Index)
+
+	require.NoError(t, srv.lastConn().Close())
+	n = getNotification(t, wsc)
+	require.Equal(t, response.ReconnectedEventID, n.Type)
+	require.Equal(t, &ReconnectEvent{LastBlockIndex: 12}, n.Value)
+	// The same filter, but no replay.
+	checkRequest(t, `subscribe["block_added",{"primary":0}]`)
+	checkRequest(t, `getblockcount[]`)
+
+	// User-visible ID doesn't change, but the new one is used for server.
+	require.NoError(t, wsc.Unsubscribe(id))
+	checkRequest(t, `unsubscribe["0"]`)
+	require.Equal(t, 0, len(wsc.subscriptions))
+
+	require.NoError(t, srv.lastConn().Close())
+	n = getNotification(t, wsc)
+	require.Equal(t, &ReconnectEvent{LastBlockIndex: 12}, n.Value)
+	checkRequest(t, `getblockcount[]`)
+	wsc.Close()
+	_, ok := <-wsc.Notifications
+	require.False(t, ok)
+}
+
+func TestWSClientReconnectIDs(t *testing.T) {
+	srv := newWSTestServer(t)
+	defer srv.Close()
+
+	checkRequest := func(t *testing.T, expected string) {
+		for {
+			select {
+			case r := <-srv.requests:
+				if r == `getblockcount[]` {
+					continue
+				}
+				require.Equal(t, expected, r)
+				return
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for request")
+			}
+		}
+	}
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Network:   netmode.UnitTestNet,
+		Reconnect: &ReconnectOptions{MinDelay: 10 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	ids := make([]string, 3)
+	for i := 0; i < 2; i++ {
+		ids[i], err = wsc.SubscribeForNewBlocks(nil)
+		require.NoError(t, err)
+		checkRequest(t, `subscribe["block_added"]`)
+	}
+	require.NoError(t, wsc.Unsubscribe(ids[0]))
+	checkRequest(t, `unsubscribe["0"]`)
+
+	require.NoError(t, srv.lastConn().Close())
+	n := <-wsc.Notifications
+	require.Equal(t, response.ReconnectedEventID, n.Type)
+	// The second subscription gets "0" from the server now, so the next
+	// one gets "1" that was the ID of the second one before reconnection.
+	checkRequest(t, `subscribe["block_added"]`)
+	ids[2], err = wsc.SubscribeForNewBlocks(nil)
+	require.NoError(t, err)
+	checkRequest(t, `subscribe["block_added"]`)
+	require.NotEqual(t, ids[1], ids[2])
+
+	require.NoError(t, wsc.Unsubscribe(ids[1]))
+	checkRequest(t, `unsubscribe["0"]`)
+	require.NoError(t, wsc.Unsubscribe(ids[2]))
+	checkRequest(t, `unsubscribe["1"]`)
+	require.Equal(t, 0, len(wsc.subscriptions))
+}
+
+func TestWSClientReconnectRejected(t *testing.T) {
+	srv := newWSTestServer(t)
+	defer srv.Close()
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Network:   netmode.UnitTestNet,
+		Reconnect: &ReconnectOptions{MinDelay: 10 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	blockID, err := wsc.SubscribeForNewBlocks(nil)
+	require.NoError(t, err)
+	txID, err := wsc.SubscribeForNewTransactions(nil, nil)
+	require.NoError(t, err)
+
+	srv.lock.Lock()
+	srv.reject = "transaction_added"
+	srv.lock.Unlock()
+	require.NoError(t, srv.lastConn().Close())
+	n := <-wsc.Notifications
+	require.Equal(t, response.ReconnectedEventID, n.Type)
+	n = <-wsc.Notifications
+	require.Equal(t, response.ResubscribeFailedEventID, n.Type)
+	ev := n.Value.(*ResubscribeFailedEvent)
+	require.Equal(t, txID, ev.ID)
+	require.Error(t, ev.Err)
+
+	// The rejected subscription is removed, the other one is restored.
+	require.Error(t, wsc.Unsubscribe(txID))
+	require.NoError(t, wsc.Unsubscribe(blockID))
+	require.Equal(t, 0, len(wsc.subscriptions))
+}
+
+func TestWSClientReconnectAttempts(t *testing.T) {
+	srv := newWSTestServer(t)
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Network:   netmode.UnitTestNet,
+		Reconnect: &ReconnectOptions{MinDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, MaxAttempts: 3},
+	})
+	require.NoError(t, err)
+	srv.lastConn().Close()
+	srv.Close()
+
+	select {
+	case _, ok := <-wsc.Notifications:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("client didn't give up")
+	}
+	_, err = wsc.GetBlockCount()
+	require.Error(t, err)
+}
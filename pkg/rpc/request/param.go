@@ -31,16 +31,30 @@ type (
 		Type  smartcontract.ParamType `json:"type"`
 		Value Param                   `json:"value"`
 	}
-	// BlockFilter is a wrapper structure for block event filter. The only
-	// allowed filter is primary index.
+	// BlockFilter is a wrapper structure for block event filter. It allows
+	// to filter blocks by primary index and by transactions contained in
+	// them (block matches if any of its transactions matches the filter).
+	// Primary index is only checked if AnyPrimary is false, JSON filter
+	// without primary field unmarshals with AnyPrimary set.
 	BlockFilter struct {
-		Primary int `json:"primary"`
+		Primary     int
+		AnyPrimary  bool
+		Transaction *TxFilter
+	}
+	// blockFilterAux is used for BlockFilter JSON marshaling.
+	blockFilterAux struct {
+		Primary     *int      `json:"primary,omitempty"`
+		Transaction *TxFilter `json:"transaction,omitempty"`
 	}
 	// TxFilter is a wrapper structure for transaction event filter. It
-	// allows to filter transactions by senders and signers.
+	// allows to filter transactions by senders and signers. Signer and
+	// Scopes are checked against the same transaction signer, so it's
+	// possible to filter transactions having any signer with the given
+	// scopes.
 	TxFilter struct {
-		Sender *util.Uint160 `json:"sender,omitempty"`
-		Signer *util.Uint160 `json:"signer,omitempty"`
+		Sender *util.Uint160             `json:"sender,omitempty"`
+		Signer *util.Uint160             `json:"signer,omitempty"`
+		Scopes *transaction.WitnessScope `json:"scopes,omitempty"`
 	}
 	// NotificationFilter is a wrapper structure representing filter used for
 	// notifications generated during transaction execution. Notifications can
@@ -51,9 +65,11 @@ type (
 	}
 	// ExecutionFilter is a wrapper structure used for transaction execution
 	// events. It allows to choose failing or successful transactions based
-	// on their VM state.
+	// on their VM state and executions containing notifications from the
+	// given contract.
 	ExecutionFilter struct {
-		State string `json:"state"`
+		State    string        `json:"state,omitempty"`
+		Contract *util.Uint160 `json:"contract,omitempty"`
 	}
 )
 
@@ -249,6 +265,30 @@ func (p Param) GetSigners() ([]transaction.Signer, error) {
 	return signers, nil
 }
 
+// MarshalJSON implements json.Marshaler interface.
+func (f BlockFilter) MarshalJSON() ([]byte, error) {
+	aux := blockFilterAux{Transaction: f.Transaction}
+	if !f.AnyPrimary {
+		aux.Primary = &f.Primary
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (f *BlockFilter) UnmarshalJSON(data []byte) error {
+	var aux blockFilterAux
+	jd := json.NewDecoder(bytes.NewReader(data))
+	jd.DisallowUnknownFields()
+	if err := jd.Decode(&aux); err != nil {
+		return err
+	}
+	*f = BlockFilter{AnyPrimary: aux.Primary == nil, Transaction: aux.Transaction}
+	if aux.Primary != nil {
+		f.Primary = *aux.Primary
+	}
+	return nil
+}
+
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (p *Param) UnmarshalJSON(data []byte) error {
 	var s string
@@ -292,7 +332,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 			case *NotificationFilter:
 				p.Value = *val
 			case *ExecutionFilter:
-				if (*val).State == "HALT" || (*val).State == "FAULT" {
+				if (*val).State == "HALT" || (*val).State == "FAULT" ||
+					((*val).State == "" && (*val).Contract != nil) {
 					p.Value = *val
 				} else {
 					continue
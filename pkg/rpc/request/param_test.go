@@ -17,6 +17,7 @@ import (
 func TestParam_UnmarshalJSON(t *testing.T) {
 	msg := `["str1", 123, null, ["str2", 3], [{"type": "String", "value": "jajaja"}],
                  {"primary": 1},
+                 {"transaction": {"sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"}},
                  {"sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"signer": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "signer": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
@@ -29,6 +30,7 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 	contr, err := util.Uint160DecodeStringLE("f84d6a337fbc3d3a201d41da99e86b479e7a2554")
 	require.NoError(t, err)
 	name := "my_pretty_notification"
+	primary := 1
 	accountHash, err := util.Uint160DecodeStringLE("cadb3dc2faa3ef14a13b619c9a43124755aa2569")
 	require.NoError(t, err)
 	expected := Params{
@@ -73,7 +75,11 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 		},
 		{
 			Type:  BlockFilterT,
-			Value: BlockFilter{Primary: 1},
+			Value: BlockFilter{Primary: primary},
+		},
+		{
+			Type:  BlockFilterT,
+			Value: BlockFilter{AnyPrimary: true, Transaction: &TxFilter{Sender: &contr}},
 		},
 		{
 			Type:  TxFilterT,
@@ -354,3 +360,21 @@ func TestParamGetSigners(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestBlockFilterJSON(t *testing.T) {
+	scopes := transaction.CalledByEntry
+	for _, f := range []BlockFilter{
+		{Primary: 0},
+		{Primary: 3, Transaction: &TxFilter{Scopes: &scopes}},
+		{AnyPrimary: true, Transaction: &TxFilter{Scopes: &scopes}},
+		{AnyPrimary: true},
+	} {
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		var actual BlockFilter
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, f, actual)
+	}
+	var f BlockFilter
+	require.Error(t, json.Unmarshal([]byte(`{"primary": 1, "sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"}`), &f))
+}
@@ -149,3 +149,15 @@ func CreateInvocationScript(contract util.Uint160, funcParams []Param) ([]byte,
 	emit.AppCall(script.BinWriter, contract)
 	return script.Bytes(), nil
 }
+
+// CreateArgumentsScript creates a script that pushes given FuncParams onto the
+// stack, so that they can be used as method arguments. It's useful for
+// invocation scripts of contract-based witnesses.
+func CreateArgumentsScript(funcParams []Param) ([]byte, error) {
+	script := io.NewBufBinWriter()
+	err := expandArrayIntoScript(script.BinWriter, funcParams)
+	if err != nil {
+		return nil, err
+	}
+	return script.Bytes(), nil
+}
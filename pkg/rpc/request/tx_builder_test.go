@@ -88,3 +88,15 @@ func TestInvocationScriptCreationBad(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func TestCreateArgumentsScript(t *testing.T) {
+	script, err := CreateArgumentsScript([]Param{
+		{Type: FuncParamT, Value: FuncParam{Type: smartcontract.IntegerType, Value: Param{Type: NumberT, Value: 42}}},
+		{Type: FuncParamT, Value: FuncParam{Type: smartcontract.BoolType, Value: Param{Type: StringT, Value: "true"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "11002a", hex.EncodeToString(script))
+
+	_, err = CreateArgumentsScript([]Param{{Type: NumberT, Value: 42}})
+	require.Error(t, err)
+}
@@ -1,7 +1,9 @@
 package request
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -45,6 +47,71 @@ type In struct {
 	RawID     json.RawMessage `json:"id,omitempty"`
 }
 
+// Batch represents a standard JSON-RPC 2.0 batch of requests:
+// http://www.jsonrpc.org/specification#batch. It's used in server to
+// represent incoming batch queries.
+type Batch []In
+
+// Request is either a single request or a batch of requests, exactly one of
+// its fields is set after successful decoding.
+type Request struct {
+	In    *In
+	Batch Batch
+}
+
+// NewRequest creates a new empty Request struct.
+func NewRequest() *Request {
+	return &Request{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Request) MarshalJSON() ([]byte, error) {
+	if r.In != nil {
+		return json.Marshal(r.In)
+	}
+	return json.Marshal(r.Batch)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if len(data) == 0 {
+		return errors.New("empty request")
+	}
+	if data[0] == '[' {
+		batch := Batch{}
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return err
+		}
+		r.In, r.Batch = nil, batch
+		return nil
+	}
+	in := new(In)
+	if err := json.Unmarshal(data, in); err != nil {
+		return err
+	}
+	r.In, r.Batch = in, nil
+	return nil
+}
+
+// DecodeData decodes the given reader into the request struct. Version is
+// only checked for single requests here, each request of a batch is to be
+// checked separately.
+func (r *Request) DecodeData(data io.ReadCloser) error {
+	defer data.Close()
+
+	err := json.NewDecoder(data).Decode(r)
+	if err != nil {
+		return fmt.Errorf("error parsing JSON payload: %w", err)
+	}
+
+	if r.In != nil && r.In.JSONRPC != JSONRPCVersion {
+		return fmt.Errorf("invalid version, expected 2.0 got: '%s'", r.In.JSONRPC)
+	}
+
+	return nil
+}
+
 // NewIn creates a new Request struct.
 func NewIn() *In {
 	return &In{
@@ -0,0 +1,65 @@
+package request
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_DecodeData(t *testing.T) {
+	decode := func(t *testing.T, s string) (*Request, error) {
+		r := NewRequest()
+		return r, r.DecodeData(ioutil.NopCloser(strings.NewReader(s)))
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		r, err := decode(t, `{"jsonrpc": "2.0", "method": "getblockcount", "params": [], "id": 1}`)
+		require.NoError(t, err)
+		require.NotNil(t, r.In)
+		require.Nil(t, r.Batch)
+		require.Equal(t, "getblockcount", r.In.Method)
+		require.Equal(t, json.RawMessage(`1`), r.In.RawID)
+	})
+	t.Run("SingleBadVersion", func(t *testing.T) {
+		_, err := decode(t, `{"jsonrpc": "1.0", "method": "getblockcount", "params": [], "id": 1}`)
+		require.Error(t, err)
+	})
+	t.Run("Batch", func(t *testing.T) {
+		r, err := decode(t, ` [{"jsonrpc": "2.0", "method": "getblockcount", "params": [], "id": 1},
+			{"jsonrpc": "1.0", "method": "getversion", "params": [], "id": "2"}]`)
+		require.NoError(t, err)
+		require.Nil(t, r.In)
+		require.Equal(t, 2, len(r.Batch))
+		require.Equal(t, "getblockcount", r.Batch[0].Method)
+		require.Equal(t, "getversion", r.Batch[1].Method)
+		require.Equal(t, "1.0", r.Batch[1].JSONRPC)
+		require.Equal(t, json.RawMessage(`"2"`), r.Batch[1].RawID)
+	})
+	t.Run("EmptyBatch", func(t *testing.T) {
+		r, err := decode(t, `[]`)
+		require.NoError(t, err)
+		require.Nil(t, r.In)
+		require.NotNil(t, r.Batch)
+		require.Equal(t, 0, len(r.Batch))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{``, `1`, `[1, 2]`, `{"jsonrpc": "2.0"`} {
+			_, err := decode(t, s)
+			require.Error(t, err, s)
+		}
+	})
+}
+
+func TestRequest_MarshalJSON(t *testing.T) {
+	in := In{JSONRPC: JSONRPCVersion, Method: "getversion", RawParams: json.RawMessage(`[]`), RawID: json.RawMessage(`1`)}
+	for _, r := range []Request{{In: &in}, {Batch: Batch{in, in}}} {
+		data, err := json.Marshal(r)
+		require.NoError(t, err)
+		actual := new(Request)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Equal(t, r, *actual)
+	}
+}
@@ -77,6 +77,18 @@ func NewInternalServerError(data string, cause error) *Error {
 	return NewError(-32603, http.StatusInternalServerError, "Internal error", data, cause)
 }
 
+// NewRateLimitError creates a new error with
+// code -32005.
+func NewRateLimitError(data string, cause error) *Error {
+	return NewError(-32005, http.StatusTooManyRequests, "Rate limit exceeded", data, cause)
+}
+
+// NewUnauthorizedError creates a new error with
+// code -32001.
+func NewUnauthorizedError(data string, cause error) *Error {
+	return NewError(-32001, http.StatusUnauthorized, "Unauthorized", data, cause)
+}
+
 // NewRPCError creates a new error with
 // code -100
 func NewRPCError(message string, data string, cause error) *Error {
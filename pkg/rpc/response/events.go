@@ -22,6 +22,19 @@ const (
 	NotificationEventID
 	// ExecutionEventID is used for `transaction_executed` events.
 	ExecutionEventID
+	// MempoolTransactionAddedEventID is used for `mempool_transaction_added`
+	// events.
+	MempoolTransactionAddedEventID
+	// MempoolTransactionRemovedEventID is used for
+	// `mempool_transaction_removed` events.
+	MempoolTransactionRemovedEventID
+	// ResubscribeFailedEventID is generated by the client itself (it's never
+	// sent by the server) to notify user of subscription that can't be
+	// restored after reconnection.
+	ResubscribeFailedEventID EventID = 253
+	// ReconnectedEventID is generated by the client itself (it's never sent
+	// by the server) to notify user of websocket reconnection.
+	ReconnectedEventID EventID = 254
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -37,6 +50,14 @@ func (e EventID) String() string {
 		return "notification_from_execution"
 	case ExecutionEventID:
 		return "transaction_executed"
+	case MempoolTransactionAddedEventID:
+		return "mempool_transaction_added"
+	case MempoolTransactionRemovedEventID:
+		return "mempool_transaction_removed"
+	case ResubscribeFailedEventID:
+		return "resubscribe_failed"
+	case ReconnectedEventID:
+		return "reconnected"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -55,6 +76,10 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotificationEventID, nil
 	case "transaction_executed":
 		return ExecutionEventID, nil
+	case "mempool_transaction_added":
+		return MempoolTransactionAddedEventID, nil
+	case "mempool_transaction_removed":
+		return MempoolTransactionRemovedEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
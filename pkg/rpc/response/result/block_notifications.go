@@ -0,0 +1,20 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// BlockNotifications represents a result of getblocknotifications RPC call.
+type BlockNotifications struct {
+	Hash          util.Uint256                 `json:"hash"`
+	Index         uint32                       `json:"index"`
+	Notifications []ContainedNotificationEvent `json:"notifications"`
+}
+
+// ContainedNotificationEvent is a notification along with the hash of its
+// container, that is either a transaction or a block (for notifications
+// emitted during block persisting).
+type ContainedNotificationEvent struct {
+	Container util.Uint256 `json:"container"`
+	NotificationEvent
+}
@@ -0,0 +1,60 @@
+package result
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+type (
+	// FindStates is a result of findstates RPC.
+	FindStates struct {
+		Results []KeyValue `json:"results"`
+		// FirstProof and LastProof are proofs of the first and the last
+		// returned items, they're only present for requests made against
+		// some state root.
+		FirstProof *ProofWithKey `json:"firstProof,omitempty"`
+		LastProof  *ProofWithKey `json:"lastProof,omitempty"`
+		// Truncated is set when there are more items to return, the key
+		// of the last returned item can be used as a start for the next
+		// request in this case.
+		Truncated bool `json:"truncated"`
+	}
+
+	// KeyValue represents contract storage key-value pair.
+	KeyValue struct {
+		Key   []byte
+		Value []byte
+	}
+
+	keyValueAux struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+)
+
+// MarshalJSON implements json.Marshaler.
+func (kv KeyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(keyValueAux{
+		Key:   hex.EncodeToString(kv.Key),
+		Value: hex.EncodeToString(kv.Value),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (kv *KeyValue) UnmarshalJSON(data []byte) error {
+	aux := new(keyValueAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(aux.Key)
+	if err != nil {
+		return err
+	}
+	value, err := hex.DecodeString(aux.Value)
+	if err != nil {
+		return err
+	}
+	kv.Key = key
+	kv.Value = value
+	return nil
+}
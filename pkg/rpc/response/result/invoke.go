@@ -13,6 +13,18 @@ type Invoke struct {
 	GasConsumed int64            `json:"gasconsumed,string"`
 	Script      string           `json:"script"`
 	Stack       []stackitem.Item `json:"stack"`
+	// Session is an ID of the server-side session holding iterators
+	// returned on the stack, it's only set if there are any.
+	Session string `json:"session,omitempty"`
+	// Trace is only set if execution tracing was requested.
+	Trace *Trace `json:"trace,omitempty"`
+}
+
+// Iterator is a reference to the server-side iterator that can be traversed
+// with traverseiterator call. It's used as a value of Interop stack items
+// in Invoke results.
+type Iterator struct {
+	ID string
 }
 
 type invokeAux struct {
@@ -20,13 +32,38 @@ type invokeAux struct {
 	GasConsumed int64           `json:"gasconsumed,string"`
 	Script      string          `json:"script"`
 	Stack       json.RawMessage `json:"stack"`
+	Session     string          `json:"session,omitempty"`
+	Trace       *Trace          `json:"trace,omitempty"`
+}
+
+type iteratorAux struct {
+	Type      string `json:"type"`
+	Interface string `json:"interface"`
+	ID        string `json:"id"`
 }
 
+// iteratorInterfaceName is used to mark iterator references in JSON.
+const iteratorInterfaceName = "IIterator"
+
 // MarshalJSON implements json.Marshaler.
 func (r Invoke) MarshalJSON() ([]byte, error) {
 	var st json.RawMessage
 	arr := make([]json.RawMessage, len(r.Stack))
 	for i := range arr {
+		if iop, ok := r.Stack[i].(*stackitem.Interop); ok {
+			if iter, ok := iop.Value().(Iterator); ok {
+				data, err := json.Marshal(iteratorAux{
+					Type:      stackitem.InteropT.String(),
+					Interface: iteratorInterfaceName,
+					ID:        iter.ID,
+				})
+				if err != nil {
+					return nil, err
+				}
+				arr[i] = data
+				continue
+			}
+		}
 		data, err := stackitem.ToJSONWithTypes(r.Stack[i])
 		if err != nil {
 			st = []byte("error: recursive reference")
@@ -47,6 +84,8 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Script:      r.Script,
 		State:       r.State,
 		Stack:       st,
+		Session:     r.Session,
+		Trace:       r.Trace,
 	})
 }
 
@@ -60,6 +99,11 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(aux.Stack, &arr); err == nil {
 		st := make([]stackitem.Item, len(arr))
 		for i := range arr {
+			iter := new(iteratorAux)
+			if json.Unmarshal(arr[i], iter) == nil && iter.Interface == iteratorInterfaceName && iter.ID != "" {
+				st[i] = stackitem.NewInterop(Iterator{ID: iter.ID})
+				continue
+			}
 			st[i], err = stackitem.FromJSONWithTypes(arr[i])
 			if err != nil {
 				break
@@ -72,5 +116,7 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.GasConsumed = aux.GasConsumed
 	r.Script = aux.Script
 	r.State = aux.State
+	r.Session = aux.Session
+	r.Trace = aux.Trace
 	return nil
 }
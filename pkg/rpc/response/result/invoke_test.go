@@ -0,0 +1,39 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoke_MarshalJSON(t *testing.T) {
+	res := &Invoke{
+		State:       "HALT",
+		GasConsumed: 237626000,
+		Script:      "10c00c04696e69740c14769162241eedf97c2481652adf1ba0f5bf57431b41627d5b52",
+		Stack: []stackitem.Item{
+			stackitem.Make(1),
+			stackitem.NewInterop(Iterator{ID: "e2f8c1fe-4b6a-4b4e-9b3a-2c7d1e0a6f11"}),
+		},
+		Session: "7b0e7a34-bd7c-4f30-b2d3-1d1f6a6f9a2c",
+	}
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"state": "HALT",
+		"gasconsumed": "237626000",
+		"script": "10c00c04696e69740c14769162241eedf97c2481652adf1ba0f5bf57431b41627d5b52",
+		"stack": [
+			{"type": "Integer", "value": "1"},
+			{"type": "Interop", "interface": "IIterator", "id": "e2f8c1fe-4b6a-4b4e-9b3a-2c7d1e0a6f11"}
+		],
+		"session": "7b0e7a34-bd7c-4f30-b2d3-1d1f6a6f9a2c"
+	}`, string(data))
+
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, res, actual)
+}
@@ -0,0 +1,14 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// MempoolEvent represents mempool_transaction_added and
+// mempool_transaction_removed notification payload. Reason is only set for
+// removals.
+type MempoolEvent struct {
+	Transaction *transaction.Transaction `json:"transaction"`
+	Reason      mempool.RemovalReason    `json:"reason,omitempty"`
+}
@@ -0,0 +1,36 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofWithKey_EncodeString(t *testing.T) {
+	expected := &ProofWithKey{
+		Key:   []byte{1, 2, 3},
+		Proof: [][]byte{{4, 5}, {6}, {}, {7, 8, 9}},
+	}
+	var actual ProofWithKey
+	require.NoError(t, actual.FromString(expected.String()))
+	require.Equal(t, expected, &actual)
+
+	testserdes.EncodeDecodeBinary(t, expected, new(ProofWithKey))
+	testserdes.MarshalUnmarshalJSON(t, expected, new(ProofWithKey))
+}
+
+func TestVerifyProof_MarshalUnmarshalJSON(t *testing.T) {
+	t.Run("Good", func(t *testing.T) {
+		vp := &VerifyProof{[]byte{1, 2, 3}}
+		testserdes.MarshalUnmarshalJSON(t, vp, new(VerifyProof))
+	})
+	t.Run("NoValue", func(t *testing.T) {
+		vp := new(VerifyProof)
+		data, err := json.Marshal(vp)
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"invalid"`), data)
+		testserdes.MarshalUnmarshalJSON(t, vp, new(VerifyProof))
+	})
+}
@@ -12,7 +12,9 @@ type NEP5Balances struct {
 	Address  string        `json:"address"`
 }
 
-// NEP5Balance represents balance for the single token contract.
+// NEP5Balance represents balance for the single token contract. Amount is a
+// decimal string using token's precision, util.BigFixedNFromString can be used
+// to get it as an integer.
 type NEP5Balance struct {
 	Asset       util.Uint160 `json:"assethash"`
 	Amount      string       `json:"amount"`
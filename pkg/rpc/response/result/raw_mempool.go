@@ -1,6 +1,13 @@
 package result
 
-import "github.com/nspcc-dev/neo-go/pkg/util"
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
 
 // RawMempool represents a result of getrawmempool RPC call.
 type RawMempool struct {
@@ -8,3 +15,73 @@ type RawMempool struct {
 	Verified   []util.Uint256 `json:"verified"`
 	Unverified []util.Uint256 `json:"unverified"`
 }
+
+// RawMempoolDetails represents a result of getrawmempool RPC call with
+// transaction details requested.
+type RawMempoolDetails struct {
+	Height       uint32               `json:"height"`
+	Transactions []MempoolTransaction `json:"transactions"`
+}
+
+// MempoolTransaction is a short description of transaction in the memory
+// pool.
+type MempoolTransaction struct {
+	Hash            util.Uint256
+	Sender          util.Uint160
+	SystemFee       int64
+	NetworkFee      int64
+	ValidUntilBlock uint32
+	Verified        bool
+}
+
+type mempoolTransactionAux struct {
+	Hash            util.Uint256 `json:"hash"`
+	Sender          string       `json:"sender"`
+	SystemFee       int64        `json:"sysfee,string"`
+	NetworkFee      int64        `json:"netfee,string"`
+	ValidUntilBlock uint32       `json:"validuntilblock"`
+	Verified        bool         `json:"verified"`
+}
+
+// NewMempoolTransaction creates a new MempoolTransaction wrapper.
+func NewMempoolTransaction(tx *transaction.Transaction, verified bool) MempoolTransaction {
+	return MempoolTransaction{
+		Hash:            tx.Hash(),
+		Sender:          tx.Sender(),
+		SystemFee:       tx.SystemFee,
+		NetworkFee:      tx.NetworkFee,
+		ValidUntilBlock: tx.ValidUntilBlock,
+		Verified:        verified,
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t MempoolTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&mempoolTransactionAux{
+		Hash:            t.Hash,
+		Sender:          address.Uint160ToString(t.Sender),
+		SystemFee:       t.SystemFee,
+		NetworkFee:      t.NetworkFee,
+		ValidUntilBlock: t.ValidUntilBlock,
+		Verified:        t.Verified,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *MempoolTransaction) UnmarshalJSON(data []byte) error {
+	aux := new(mempoolTransactionAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	sender, err := address.StringToUint160(aux.Sender)
+	if err != nil {
+		return errors.New("invalid sender address")
+	}
+	t.Hash = aux.Hash
+	t.Sender = sender
+	t.SystemFee = aux.SystemFee
+	t.NetworkFee = aux.NetworkFee
+	t.ValidUntilBlock = aux.ValidUntilBlock
+	t.Verified = aux.Verified
+	return nil
+}
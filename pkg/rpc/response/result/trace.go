@@ -0,0 +1,205 @@
+package result
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Trace is an execution trace returned by invokefunction and invokescript
+// calls when tracing is requested.
+type Trace struct {
+	Calls         *CallFrame          `json:"calls"`
+	Storage       []StorageChange     `json:"storage"`
+	Notifications []NotificationEvent `json:"notifications"`
+	Logs          []LogEvent          `json:"logs"`
+	Exception     *ExceptionLocation  `json:"exception,omitempty"`
+}
+
+// CallFrame is a node of the contract call tree, GasConsumed includes GAS
+// spent by nested calls.
+type CallFrame struct {
+	Contract    util.Uint160 `json:"contract"`
+	Method      string       `json:"method,omitempty"`
+	GasConsumed int64        `json:"gasconsumed,string"`
+	Calls       []CallFrame  `json:"calls,omitempty"`
+}
+
+// StorageChange is a storage item modification. OldValue is nil if there
+// was no such item before the change, NewValue is nil for deletions.
+type StorageChange struct {
+	Contract util.Uint160
+	ID       int32
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+	Deleted  bool
+}
+
+type storageChangeAux struct {
+	Contract util.Uint160 `json:"contract"`
+	ID       int32        `json:"id"`
+	Key      string       `json:"key"`
+	OldValue *string      `json:"old"`
+	NewValue *string      `json:"new"`
+	Deleted  bool         `json:"deleted,omitempty"`
+}
+
+// LogEvent is a message logged by the contract.
+type LogEvent struct {
+	Contract util.Uint160 `json:"contract"`
+	Message  string       `json:"message"`
+}
+
+// ExceptionLocation points to the instruction that caused VM to FAULT.
+type ExceptionLocation struct {
+	Contract util.Uint160
+	IP       int
+	Opcode   opcode.Opcode
+	Message  string
+}
+
+type exceptionLocationAux struct {
+	Contract util.Uint160 `json:"contract"`
+	IP       int          `json:"ip"`
+	Opcode   string       `json:"opcode"`
+	Message  string       `json:"message"`
+}
+
+// NewTrace creates a new Trace wrapper.
+func NewTrace(t *state.ExecutionTrace) *Trace {
+	res := &Trace{
+		Storage:       make([]StorageChange, 0, len(t.Storage)),
+		Notifications: make([]NotificationEvent, 0, len(t.Notifications)),
+		Logs:          make([]LogEvent, 0, len(t.Logs)),
+	}
+	if t.Calls != nil {
+		f := newCallFrame(t.Calls)
+		res.Calls = &f
+	}
+	for _, c := range t.Storage {
+		res.Storage = append(res.Storage, StorageChange{
+			Contract: c.ScriptHash,
+			ID:       c.ID,
+			Key:      c.Key,
+			OldValue: c.OldValue,
+			NewValue: c.NewValue,
+			Deleted:  c.Deleted,
+		})
+	}
+	for _, e := range t.Notifications {
+		res.Notifications = append(res.Notifications, StateEventToResultNotification(e))
+	}
+	for _, l := range t.Logs {
+		res.Logs = append(res.Logs, LogEvent{
+			Contract: l.ScriptHash,
+			Message:  l.Message,
+		})
+	}
+	if t.Exception != nil {
+		res.Exception = &ExceptionLocation{
+			Contract: t.Exception.ScriptHash,
+			IP:       t.Exception.IP,
+			Opcode:   t.Exception.Opcode,
+			Message:  t.Exception.Message,
+		}
+	}
+	return res
+}
+
+func newCallFrame(f *state.CallFrame) CallFrame {
+	res := CallFrame{
+		Contract:    f.ScriptHash,
+		Method:      f.Method,
+		GasConsumed: f.GasConsumed,
+	}
+	for _, c := range f.Calls {
+		res.Calls = append(res.Calls, newCallFrame(c))
+	}
+	return res
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c StorageChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&storageChangeAux{
+		Contract: c.Contract,
+		ID:       c.ID,
+		Key:      hex.EncodeToString(c.Key),
+		OldValue: hexOrNil(c.OldValue),
+		NewValue: hexOrNil(c.NewValue),
+		Deleted:  c.Deleted,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *StorageChange) UnmarshalJSON(data []byte) error {
+	aux := new(storageChangeAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(aux.Key)
+	if err != nil {
+		return fmt.Errorf("invalid key: %w", err)
+	}
+	oldValue, err := decodeHexOrNil(aux.OldValue)
+	if err != nil {
+		return fmt.Errorf("invalid old value: %w", err)
+	}
+	newValue, err := decodeHexOrNil(aux.NewValue)
+	if err != nil {
+		return fmt.Errorf("invalid new value: %w", err)
+	}
+	c.Contract = aux.Contract
+	c.ID = aux.ID
+	c.Key = key
+	c.OldValue = oldValue
+	c.NewValue = newValue
+	c.Deleted = aux.Deleted
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l ExceptionLocation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&exceptionLocationAux{
+		Contract: l.Contract,
+		IP:       l.IP,
+		Opcode:   l.Opcode.String(),
+		Message:  l.Message,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *ExceptionLocation) UnmarshalJSON(data []byte) error {
+	aux := new(exceptionLocationAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	op, err := opcode.FromString(aux.Opcode)
+	if err != nil {
+		return err
+	}
+	l.Contract = aux.Contract
+	l.IP = aux.IP
+	l.Opcode = op
+	l.Message = aux.Message
+	return nil
+}
+
+func hexOrNil(b []byte) *string {
+	if b == nil {
+		return nil
+	}
+	s := hex.EncodeToString(b)
+	return &s
+}
+
+func decodeHexOrNil(s *string) ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return hex.DecodeString(*s)
+}
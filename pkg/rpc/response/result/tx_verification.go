@@ -0,0 +1,34 @@
+package result
+
+import "github.com/nspcc-dev/neo-go/pkg/util"
+
+// TxFailureReason is a machine-readable reason of transaction verification
+// failure.
+type TxFailureReason string
+
+// Transaction verification failure reasons.
+const (
+	TxAlreadyExists     TxFailureReason = "alreadyexists"
+	TxConflict          TxFailureReason = "conflict"
+	TxExpired           TxFailureReason = "expired"
+	TxInsufficientFunds TxFailureReason = "insufficientfunds"
+	TxInvalidAttribute  TxFailureReason = "invalidattribute"
+	TxOutOfMemory       TxFailureReason = "outofmemory"
+	TxPolicy            TxFailureReason = "policy"
+	TxSmallNetworkFee   TxFailureReason = "smallnetworkfee"
+	TxTooBig            TxFailureReason = "toobig"
+	TxWitness           TxFailureReason = "witness"
+	TxUnknown           TxFailureReason = "unknown"
+)
+
+// TxVerification is a result of the `testsendrawtransaction` RPC call. Reason
+// and Message are only set for invalid transactions, Signer is the index of
+// the signer with invalid witness for TxWitness failures (it's nil if the
+// number of witnesses doesn't match the number of signers).
+type TxVerification struct {
+	Hash    util.Uint256    `json:"hash"`
+	Valid   bool            `json:"valid"`
+	Reason  TxFailureReason `json:"reason,omitempty"`
+	Signer  *int            `json:"signer,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
@@ -26,6 +26,10 @@ type Raw struct {
 	Result json.RawMessage `json:"result,omitempty"`
 }
 
+// Batch represents a standard JSON-RPC 2.0 batch of raw responses:
+// http://www.jsonrpc.org/specification#batch.
+type Batch []Raw
+
 // GetRawTx represents verbose output of `getrawtransaction` RPC call.
 type GetRawTx struct {
 	HeaderAndError
@@ -5,14 +5,76 @@ import "github.com/nspcc-dev/neo-go/pkg/util"
 type (
 	// Config is an RPC service configuration information
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address string `yaml:"Address"`
+		// Auth is an optional client authentication configuration.
+		Auth AuthConfig `yaml:"Auth"`
+		// AllowedMethods is a list of methods served by the server,
+		// all methods are allowed if it's empty.
+		AllowedMethods []string `yaml:"AllowedMethods"`
+		// DeniedMethods is a list of methods that are not served
+		// by the server, it's checked after AllowedMethods.
+		DeniedMethods        []string `yaml:"DeniedMethods"`
+		Enabled              bool     `yaml:"Enabled"`
+		EnableCORSWorkaround bool     `yaml:"EnableCORSWorkaround"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
 		MaxGasInvoke util.Fixed8 `yaml:"MaxGasInvoke"`
-		Port         uint16      `yaml:"Port"`
-		TLSConfig    TLSConfig   `yaml:"TLSConfig"`
+		// MaxFindResultItems is a maximum number of items
+		// returned by a single findstates call.
+		MaxFindResultItems int `yaml:"MaxFindResultItems"`
+		// MaxBatchSize is a maximum number of requests
+		// in a single JSON-RPC batch.
+		MaxBatchSize int `yaml:"MaxBatchSize"`
+		// MaxIteratorResultItems is a maximum number of items
+		// returned by a single traverseiterator call.
+		MaxIteratorResultItems int `yaml:"MaxIteratorResultItems"`
+		// MaxReplayDepth is a maximum number of blocks events of which
+		// can be replayed for a single subscription.
+		MaxReplayDepth int `yaml:"MaxReplayDepth"`
+		// MaxReplayPending is a maximum number of live events kept
+		// for a subscription while its replay is in progress.
+		MaxReplayPending int `yaml:"MaxReplayPending"`
+		// MaxRequestBodyBytes is a maximum size of HTTP request
+		// body.
+		MaxRequestBodyBytes int    `yaml:"MaxRequestBodyBytes"`
+		Port                uint16 `yaml:"Port"`
+		// RateLimit is a maximum number of requests per second
+		// allowed for a single client (IP address), 0 means no
+		// limit. Every request in a batch is counted.
+		RateLimit int `yaml:"RateLimit"`
+		// RateLimitBurst is a maximum number of requests a client
+		// can make at once, it can't be less than RateLimit.
+		RateLimitBurst int `yaml:"RateLimitBurst"`
+		// SessionEnabled enables iterator sessions, iterators
+		// returned by invocations are kept on the server and can
+		// be traversed with traverseiterator call.
+		SessionEnabled bool `yaml:"SessionEnabled"`
+		// SessionExpirationTime is a session lifetime in seconds
+		// since the last access to it.
+		SessionExpirationTime int `yaml:"SessionExpirationTime"`
+		// SessionPoolSize is a maximum number of concurrent
+		// iterator sessions.
+		SessionPoolSize int       `yaml:"SessionPoolSize"`
+		TLSConfig       TLSConfig `yaml:"TLSConfig"`
+	}
+
+	// AuthConfig describes client authentication configuration,
+	// authentication is enabled if any credentials are specified.
+	AuthConfig struct {
+		// BasicUsers maps user names to passwords for HTTP Basic
+		// authentication.
+		BasicUsers map[string]string `yaml:"BasicUsers"`
+		// BearerTokens is a list of static tokens accepted in
+		// "Authorization: Bearer" header.
+		BearerTokens []string `yaml:"BearerTokens"`
+		// JWTSecret is a secret used to check HMAC-SHA256 signatures of
+		// JWT bearer tokens, JWTs are not accepted if it's empty.
+		JWTSecret string `yaml:"JWTSecret"`
+		// PublicMethods is a list of methods available to
+		// unauthenticated clients, all methods except
+		// sendrawtransaction and submitblock are available if it's
+		// empty.
+		PublicMethods []string `yaml:"PublicMethods"`
 	}
 
 	// TLSConfig describes SSL/TLS configuration.
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+)
+
+// authenticator checks client credentials according to rpc.AuthConfig.
+type authenticator struct {
+	users         map[string]string
+	tokens        []string
+	jwtSecret     []byte
+	publicMethods map[string]bool
+}
+
+// Methods that are not available to unauthenticated clients by default.
+var defaultProtectedMethods = []string{"sendrawtransaction", "submitblock"}
+
+// JWT header and claims we're interested in.
+type (
+	jwtHeader struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+	}
+	jwtClaims struct {
+		Exp *int64 `json:"exp"`
+		Nbf *int64 `json:"nbf"`
+	}
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+// newAuthenticator creates a new authenticator from the given configuration,
+// it returns nil if there are no credentials configured.
+func newAuthenticator(cfg rpc.AuthConfig) *authenticator {
+	if len(cfg.BasicUsers) == 0 && len(cfg.BearerTokens) == 0 && cfg.JWTSecret == "" {
+		return nil
+	}
+	a := &authenticator{
+		users:         cfg.BasicUsers,
+		tokens:        cfg.BearerTokens,
+		publicMethods: makeMethodSet(cfg.PublicMethods),
+	}
+	if cfg.JWTSecret != "" {
+		a.jwtSecret = []byte(cfg.JWTSecret)
+	}
+	if a.publicMethods == nil {
+		a.publicMethods = make(map[string]bool)
+		for m := range rpcHandlers {
+			a.publicMethods[m] = true
+		}
+		for m := range rpcWsHandlers {
+			a.publicMethods[m] = true
+		}
+		for _, m := range defaultProtectedMethods {
+			delete(a.publicMethods, m)
+		}
+	}
+	return a
+}
+
+// authenticate checks credentials given in the request. It returns false
+// for requests without credentials and an error if they're invalid.
+func (a *authenticator) authenticate(r *http.Request) (bool, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return false, nil
+	}
+	if user, password, ok := r.BasicAuth(); ok {
+		expected, ok := a.users[user]
+		if !ok || subtle.ConstantTimeCompare([]byte(expected), []byte(password)) != 1 {
+			return false, errors.New("invalid user name or password")
+		}
+		return true, nil
+	}
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return false, errors.New("unsupported authentication scheme")
+	}
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	for i := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(a.tokens[i]), []byte(token)) == 1 {
+			return true, nil
+		}
+	}
+	if a.jwtSecret != nil {
+		if err := a.verifyJWT(token, time.Now()); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, errInvalidToken
+}
+
+// verifyJWT checks HMAC-SHA256 signature and expiration of the given JWT.
+func (a *authenticator) verifyJWT(token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errInvalidToken
+	}
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return errInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errInvalidToken
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errInvalidToken
+	}
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return errInvalidToken
+	}
+	if claims.Exp != nil && now.Unix() >= *claims.Exp {
+		return errors.New("token is expired")
+	}
+	if claims.Nbf != nil && now.Unix() < *claims.Nbf {
+		return errors.New("token is not valid yet")
+	}
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// authenticate checks request credentials, every client is treated as
+// authenticated if authentication is not configured.
+func (s *Server) authenticate(r *http.Request) (bool, *response.Error) {
+	if s.auth == nil {
+		return true, nil
+	}
+	ok, err := s.auth.authenticate(r)
+	if err != nil {
+		incRejectedCounter(rejectedByAuth)
+		return false, response.NewUnauthorizedError(err.Error(), err)
+	}
+	return ok, nil
+}
+
+// isMethodAvailable checks whether the method can be called by
+// (un)authenticated client.
+func (s *Server) isMethodAvailable(method string, authenticated bool) bool {
+	return authenticated || s.auth == nil || s.auth.publicMethods[method]
+}
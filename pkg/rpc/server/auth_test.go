@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "jwt secret"
+
+func makeJWT(alg string, claims string, secret string) string {
+	enc := base64.RawURLEncoding
+	data := enc.EncodeToString([]byte(`{"alg":"`+alg+`","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return data + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	a := newAuthenticator(rpc.AuthConfig{JWTSecret: testJWTSecret})
+	now := time.Unix(1600000000, 0)
+
+	require.NoError(t, a.verifyJWT(makeJWT("HS256", `{"sub":"partner"}`, testJWTSecret), now))
+	require.NoError(t, a.verifyJWT(makeJWT("HS256", `{"exp":1600000001,"nbf":1600000000}`, testJWTSecret), now))
+	require.Error(t, a.verifyJWT(makeJWT("HS256", `{"exp":1600000000}`, testJWTSecret), now))
+	require.Error(t, a.verifyJWT(makeJWT("HS256", `{"nbf":1600000001}`, testJWTSecret), now))
+	require.Error(t, a.verifyJWT(makeJWT("HS256", `{}`, "wrong secret"), now))
+	require.Error(t, a.verifyJWT(makeJWT("none", `{}`, testJWTSecret), now))
+	require.Error(t, a.verifyJWT(makeJWT("HS256", `not a json`, testJWTSecret), now))
+	require.Error(t, a.verifyJWT("a.b", now))
+	require.Error(t, a.verifyJWT("a.b.c", now))
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	require.Nil(t, newAuthenticator(rpc.AuthConfig{PublicMethods: []string{"getversion"}}))
+
+	a := newAuthenticator(rpc.AuthConfig{BearerTokens: []string{"token"}})
+	require.True(t, a.publicMethods["getblockcount"])
+	require.True(t, a.publicMethods["subscribe"])
+	require.False(t, a.publicMethods["sendrawtransaction"])
+	require.False(t, a.publicMethods["submitblock"])
+}
+
+func TestServerAuth(t *testing.T) {
+	const rpcCall = `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": []}`
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *rpc.Config) {
+		c.Auth = rpc.AuthConfig{
+			BasicUsers:    map[string]string{"user": "password"},
+			BearerTokens:  []string{"static token"},
+			JWTSecret:     testJWTSecret,
+			PublicMethods: []string{"getversion"},
+		}
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	unauthorized := response.NewUnauthorizedError("", nil).Code
+	wsURL := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getversion"), httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrCode(t, body, unauthorized)
+		body = doRPCCallOverWS(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrCode(t, body, unauthorized)
+
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+		require.NoError(t, err)
+		_, err = c.GetVersion()
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+	})
+
+	good := map[string]client.Options{
+		"Basic":     {User: "user", Password: "password"},
+		"Static":    {BearerToken: "static token"},
+		"JWT":       {BearerToken: makeJWT("HS256", `{"sub":"partner"}`, testJWTSecret)},
+		"JWTExpiry": {BearerToken: makeJWT("HS256", fmt.Sprintf(`{"exp":%d}`, time.Now().Unix()+3600), testJWTSecret)},
+	}
+	for name, opts := range good {
+		opts.Network = netmode.UnitTestNet
+		t.Run(name, func(t *testing.T) {
+			c, err := client.New(context.Background(), httpSrv.URL, opts)
+			require.NoError(t, err)
+			_, err = c.GetBlockCount()
+			require.NoError(t, err)
+
+			wsc, err := client.NewWS(context.Background(), wsURL, opts)
+			require.NoError(t, err)
+			defer wsc.Close()
+			_, err = wsc.GetBlockCount()
+			require.NoError(t, err)
+		})
+	}
+
+	bad := map[string]client.Options{
+		"BadPassword": {User: "user", Password: "drowssap"},
+		"BadUser":     {User: "resu", Password: "password"},
+		"BadToken":    {BearerToken: "token"},
+		"BadJWT":      {BearerToken: makeJWT("HS256", `{}`, "wrong secret")},
+		"ExpiredJWT":  {BearerToken: makeJWT("HS256", fmt.Sprintf(`{"exp":%d}`, time.Now().Unix()-1), testJWTSecret)},
+	}
+	for name, opts := range bad {
+		opts.Network = netmode.UnitTestNet
+		t.Run(name, func(t *testing.T) {
+			c, err := client.New(context.Background(), httpSrv.URL, opts)
+			require.NoError(t, err)
+			_, err = c.GetVersion()
+			require.Error(t, err)
+
+			_, err = client.NewWS(context.Background(), wsURL, opts)
+			require.Error(t, err)
+		})
+	}
+}
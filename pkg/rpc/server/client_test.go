@@ -2,18 +2,26 @@ package server
 
 import (
 	"context"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -37,7 +45,7 @@ func TestClient_NEP5(t *testing.T) {
 	t.Run("TotalSupply", func(t *testing.T) {
 		s, err := c.NEP5TotalSupply(h)
 		require.NoError(t, err)
-		require.EqualValues(t, 1_000_000, s)
+		require.Equal(t, int64(1_000_000), s.Int64())
 	})
 	t.Run("Name", func(t *testing.T) {
 		name, err := c.NEP5Name(h)
@@ -61,7 +69,196 @@ func TestClient_NEP5(t *testing.T) {
 		acc := testchain.PrivateKeyByID(0).GetScriptHash()
 		b, err := c.NEP5BalanceOf(h, acc)
 		require.NoError(t, err)
-		require.EqualValues(t, 877, b)
+		require.Equal(t, int64(877), b.Int64())
+	})
+}
+
+func TestClient_InvokeHistoric(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+	require.NoError(t, err)
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+	params := []smartcontract.Parameter{{Type: smartcontract.Hash160Type, Value: acc}}
+	checkBalance := func(t *testing.T, res *result.Invoke, err error, expected int64) {
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 1, len(res.Stack))
+		b, err := res.Stack[0].TryInteger()
+		require.NoError(t, err)
+		require.Equal(t, expected, b.Int64())
+	}
+
+	t.Run("AtHeight", func(t *testing.T) {
+		res, err := c.InvokeFunctionAtHeight(0, h, "balanceOf", params, nil)
+		checkBalance(t, res, err, 0)
+
+		res, err = c.InvokeFunctionAtHeight(chain.BlockHeight(), h, "balanceOf", params, nil)
+		checkBalance(t, res, err, 877)
+	})
+	t.Run("WithState", func(t *testing.T) {
+		r, err := chain.GetStateRoot(chain.BlockHeight())
+		require.NoError(t, err)
+		res, err := c.InvokeFunctionWithState(r.Root, h, "balanceOf", params, nil)
+		checkBalance(t, res, err, 877)
+
+		script := res.Script
+		r, err = chain.GetStateRoot(0)
+		require.NoError(t, err)
+		rawScript, err := hex.DecodeString(script)
+		require.NoError(t, err)
+		res, err = c.InvokeScriptWithState(r.Root, rawScript, nil)
+		checkBalance(t, res, err, 0)
+	})
+	t.Run("UnknownHeight", func(t *testing.T) {
+		_, err := c.InvokeFunctionAtHeight(chain.BlockHeight()+1, h, "balanceOf", params, nil)
+		require.Error(t, err)
+	})
+	t.Run("DoesNotAffectState", func(t *testing.T) {
+		res, err := c.InvokeFunction(h, "balanceOf", params, nil)
+		checkBalance(t, res, err, 877)
+	})
+}
+
+func TestClient_Batch(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	check := func(t *testing.T, c *client.Client) {
+		var (
+			count uint32
+			h     util.Uint256
+			ver   result.Version
+			b     = c.NewBatch()
+		)
+		require.Equal(t, 0, b.Add("getblockcount", request.NewRawParams(), &count))
+		require.Equal(t, 1, b.Add("getblockhash", request.NewRawParams(1), &h))
+		require.Equal(t, 2, b.Add("unknownmethod", request.NewRawParams(), new(string)))
+		require.Equal(t, 3, b.Add("getversion", request.NewRawParams(), &ver))
+		require.Equal(t, 4, b.Len())
+
+		errs, err := b.Send()
+		require.NoError(t, err)
+		require.Equal(t, 4, len(errs))
+		require.NoError(t, errs[0])
+		require.Equal(t, chain.BlockHeight()+1, count)
+		require.NoError(t, errs[1])
+		require.Equal(t, chain.GetHeaderHash(1), h)
+		require.Error(t, errs[2])
+		require.NoError(t, errs[3])
+		require.NotEmpty(t, ver.UserAgent)
+	}
+
+	t.Run("HTTP", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+		require.NoError(t, err)
+		check(t, c)
+	})
+	t.Run("WS", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+		c, err := client.NewWS(context.Background(), url, client.Options{Network: netmode.UnitTestNet})
+		require.NoError(t, err)
+		defer c.Close()
+		check(t, &c.Client)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+		require.NoError(t, err)
+		_, err = c.NewBatch().Send()
+		require.Error(t, err)
+	})
+	t.Run("TooBig", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+		require.NoError(t, err)
+		b := c.NewBatch()
+		for i := 0; i <= defaultMaxBatchSize; i++ {
+			b.Add("getblockcount", request.NewRawParams(), new(uint32))
+		}
+		_, err = b.Send()
+		require.Error(t, err)
+	})
+}
+
+func TestClient_Iterator(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: netmode.UnitTestNet})
+	require.NoError(t, err)
+
+	w := io.NewBufBinWriter()
+	emit.Array(w.BinWriter, int64(1), int64(2), int64(3), int64(4), int64(5))
+	emit.Syscall(w.BinWriter, interopnames.SystemIteratorCreate)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	invoke := func(t *testing.T) (string, string) {
+		res, err := c.InvokeScript(script, nil)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.NotEmpty(t, res.Session)
+		require.Equal(t, 1, len(res.Stack))
+		iop, ok := res.Stack[0].(*stackitem.Interop)
+		require.True(t, ok)
+		iter, ok := iop.Value().(result.Iterator)
+		require.True(t, ok)
+		return res.Session, iter.ID
+	}
+
+	t.Run("Traverse", func(t *testing.T) {
+		sess, iter := invoke(t)
+		items, err := c.TraverseIterator(sess, iter, 2)
+		require.NoError(t, err)
+		require.Equal(t, []stackitem.Item{
+			stackitem.NewStruct([]stackitem.Item{stackitem.Make(0), stackitem.Make(1)}),
+			stackitem.NewStruct([]stackitem.Item{stackitem.Make(1), stackitem.Make(2)}),
+		}, items)
+		items, err = c.TraverseIterator(sess, iter, 10)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(items))
+		items, err = c.TraverseIterator(sess, iter, 10)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(items))
+
+		_, err = c.TraverseIterator(sess, iter, 0)
+		require.Error(t, err)
+		_, err = c.TraverseIterator(sess, iter, defaultMaxIteratorResultItems+1)
+		require.Error(t, err)
+		_, err = c.TraverseIterator(sess, sess, 1)
+		require.Error(t, err)
+
+		ok, err := c.TerminateSession(sess)
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = c.TerminateSession(sess)
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, err = c.TraverseIterator(sess, iter, 1)
+		require.Error(t, err)
+	})
+	t.Run("NoIterators", func(t *testing.T) {
+		res, err := c.InvokeScript([]byte{byte(opcode.PUSH1)}, nil)
+		require.NoError(t, err)
+		require.Empty(t, res.Session)
+	})
+	t.Run("PoolSize", func(t *testing.T) {
+		rpcSrv.config.SessionPoolSize = 1
+		defer func() { rpcSrv.config.SessionPoolSize = defaultSessionPoolSize }()
+
+		sess, _ := invoke(t)
+		_, err := c.InvokeScript(script, nil)
+		require.Error(t, err)
+		ok, err := c.TerminateSession(sess)
+		require.NoError(t, err)
+		require.True(t, ok)
+		invoke(t)
 	})
 }
 
@@ -133,6 +330,88 @@ func TestAddNetworkFee(t *testing.T) {
 	})
 }
 
+func TestCalculateNetworkFee(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{Network: testchain.Network()})
+	require.NoError(t, err)
+
+	newTx := func(t *testing.T, accs ...*wallet.Account) *transaction.Transaction {
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		for i, acc := range accs {
+			scope := transaction.CalledByEntry
+			if i == 0 {
+				scope = transaction.FeeOnly
+			}
+			tx.Signers = append(tx.Signers, transaction.Signer{
+				Account: acc.Contract.ScriptHash(),
+				Scopes:  scope,
+			})
+		}
+		return tx
+	}
+	withScripts := func(tx *transaction.Transaction, accs ...*wallet.Account) *transaction.Transaction {
+		res := *tx
+		for _, acc := range accs {
+			res.Scripts = append(res.Scripts, transaction.Witness{VerificationScript: acc.Contract.Script})
+		}
+		return &res
+	}
+
+	t.Run("Standard", func(t *testing.T) {
+		acc0, err := wallet.NewAccount()
+		require.NoError(t, err)
+		acc1, err := wallet.NewAccount()
+		require.NoError(t, err)
+		acc2, err := wallet.NewAccount()
+		require.NoError(t, err)
+		pubs := keys.PublicKeys{acc1.PrivateKey().PublicKey(), acc2.PrivateKey().PublicKey()}
+		require.NoError(t, acc1.ConvertMultisig(2, pubs))
+
+		tx := newTx(t, acc0, acc1)
+		fee, err := c.CalculateNetworkFee(withScripts(tx, acc0, acc1))
+		require.NoError(t, err)
+		require.NoError(t, c.AddNetworkFee(tx, 0, acc0, acc1))
+		require.Equal(t, tx.NetworkFee, fee)
+	})
+	t.Run("NonStandard", func(t *testing.T) {
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		verif := []byte{byte(opcode.PUSH1)}
+		tx.Signers = []transaction.Signer{{Account: hash.Hash160(verif)}}
+		tx.Scripts = []transaction.Witness{{VerificationScript: verif}}
+		fee, err := c.CalculateNetworkFee(tx)
+		require.NoError(t, err)
+		v, err := chain.GetTestVerificationVM(tx, hash.Hash160(verif), &tx.Scripts[0])
+		require.NoError(t, err)
+		require.NoError(t, v.Run())
+		require.Equal(t, int64(io.GetVarSize(tx))*chain.FeePerByte()+v.GasConsumed(), fee)
+	})
+	t.Run("MissingVerificationScript", func(t *testing.T) {
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		_, err = c.CalculateNetworkFee(newTx(t, acc))
+		require.Error(t, err)
+	})
+	t.Run("WitnessMismatch", func(t *testing.T) {
+		acc0, err := wallet.NewAccount()
+		require.NoError(t, err)
+		acc1, err := wallet.NewAccount()
+		require.NoError(t, err)
+		_, err = c.CalculateNetworkFee(withScripts(newTx(t, acc0), acc1))
+		require.Error(t, err)
+	})
+	t.Run("NoVerifyMethod", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(testContractHash)
+		require.NoError(t, err)
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		tx.Signers = []transaction.Signer{{Account: h}}
+		_, err = c.CalculateNetworkFee(tx)
+		require.Error(t, err)
+	})
+}
+
 func TestSignAndPushInvocationTx(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
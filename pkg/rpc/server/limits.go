@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+)
+
+type (
+	// rateLimiter is a per-client token bucket rate limiter. Each client
+	// (identified by IP address) has a bucket of burst tokens that is
+	// refilled at rate tokens per second, every request takes one token.
+	rateLimiter struct {
+		lock      sync.Mutex
+		rate      float64
+		burst     float64
+		buckets   map[string]*tokenBucket
+		lastSweep time.Time
+	}
+
+	tokenBucket struct {
+		tokens float64
+		last   time.Time
+	}
+)
+
+// newRateLimiter creates a new rateLimiter, it returns nil if rate is not
+// positive (which means no limit).
+func newRateLimiter(rate int, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < rate {
+		burst = rate
+	}
+	return &rateLimiter{
+		rate:    float64(rate),
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow checks whether n more requests can be processed for the given client
+// at the given time and takes tokens for them if so.
+func (l *rateLimiter) allow(client string, n int, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// sweep removes buckets that are full already (so they're no different from
+// the new ones), it's done not more often than once per refill period.
+func (l *rateLimiter) sweep(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, client)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Request rejection reasons used for metrics.
+const (
+	rejectedByMethod    = "method"
+	rejectedByRateLimit = "rate_limit"
+	rejectedByBodySize  = "body_size"
+	rejectedByAuth      = "auth"
+)
+
+// Default maximum size of HTTP request body.
+const defaultMaxRequestBodyBytes = 5 * 1024 * 1024
+
+// clientIP returns IP address from the given remote address of the client.
+func clientIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// readRequestBody reads and closes the given request body, it returns true
+// if the body is bigger than max bytes (not reading it completely then).
+func readRequestBody(body io.ReadCloser, max int) ([]byte, bool, error) {
+	defer body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(body, int64(max)+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) > max {
+		return nil, true, nil
+	}
+	return data, false, nil
+}
+
+// checkRateLimit takes tokens for n requests made by the given client, it
+// returns an error if the limit is exceeded.
+func (s *Server) checkRateLimit(client string, n int) *response.Error {
+	if n < 1 {
+		n = 1
+	}
+	if s.limiter == nil || s.limiter.allow(client, n, time.Now()) {
+		return nil
+	}
+	incRejectedCounter(rejectedByRateLimit)
+	return response.NewRateLimitError(fmt.Sprintf("no more than %d requests per second are allowed", s.config.RateLimit), nil)
+}
+
+// isMethodAllowed checks method against allowed and denied lists from the
+// configuration.
+func (s *Server) isMethodAllowed(method string) bool {
+	if len(s.allowedMethods) != 0 && !s.allowedMethods[method] {
+		return false
+	}
+	return !s.deniedMethods[method]
+}
+
+// makeMethodSet converts list of methods into a set.
+func makeMethodSet(methods []string) map[string]bool {
+	if len(methods) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return set
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require.Nil(t, newRateLimiter(0, 10))
+
+	l := newRateLimiter(2, 4)
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		require.True(t, l.allow("1.2.3.4", 1, now))
+	}
+	require.False(t, l.allow("1.2.3.4", 1, now))
+	require.True(t, l.allow("4.3.2.1", 4, now))
+
+	now = now.Add(500 * time.Millisecond)
+	require.False(t, l.allow("1.2.3.4", 2, now))
+	require.True(t, l.allow("1.2.3.4", 1, now))
+	require.False(t, l.allow("1.2.3.4", 1, now))
+
+	// Bucket can't contain more than burst tokens.
+	now = now.Add(time.Minute)
+	require.False(t, l.allow("1.2.3.4", 5, now))
+	require.True(t, l.allow("1.2.3.4", 4, now))
+	// Full buckets are removed.
+	require.Equal(t, 1, len(l.buckets))
+
+	t.Run("BurstLessThanRate", func(t *testing.T) {
+		l := newRateLimiter(3, 1)
+		require.True(t, l.allow("1.2.3.4", 3, time.Now()))
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	require.Equal(t, "127.0.0.1", clientIP("127.0.0.1:20332"))
+	require.Equal(t, "::1", clientIP("[::1]:20332"))
+	require.Equal(t, "something", clientIP("something"))
+}
+
+func checkErrCode(t *testing.T, body []byte, code int64) {
+	checkErrGetResult(t, body, true)
+	raw := new(response.Raw)
+	require.NoError(t, json.Unmarshal(body, raw))
+	require.Equal(t, code, raw.Error.Code)
+}
+
+func TestServerLimits(t *testing.T) {
+	const rpcCall = `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": []}`
+
+	methodNotFound := response.NewMethodNotFoundError("", nil).Code
+
+	t.Run("Methods", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *rpc.Config) {
+			c.AllowedMethods = []string{"getblockcount", "getversion", "submitblock"}
+			c.DeniedMethods = []string{"submitblock"}
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getbestblockhash"), httpSrv.URL, t)
+		checkErrCode(t, body, methodNotFound)
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "submitblock"), httpSrv.URL, t)
+		checkErrCode(t, body, methodNotFound)
+		body = doRPCCallOverWS(fmt.Sprintf(rpcCall, "submitblock"), httpSrv.URL, t)
+		checkErrCode(t, body, methodNotFound)
+	})
+	t.Run("BodySize", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *rpc.Config) {
+			c.MaxRequestBodyBytes = 100
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		call := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": ["` + strings.Repeat("0", 100) + `"]}`
+		body = doRPCCallOverHTTP(call, httpSrv.URL, t)
+		checkErrCode(t, body, response.NewInvalidRequestError("", nil).Code)
+	})
+	t.Run("RateLimit", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *rpc.Config) {
+			c.RateLimit = 1
+			c.RateLimitBurst = 3
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		batch := "[" + fmt.Sprintf(rpcCall, "getblockcount") + "," + fmt.Sprintf(rpcCall, "getversion") + "]"
+		body := doRPCCallOverHTTP(batch, httpSrv.URL, t)
+		var resps response.Batch
+		require.NoError(t, json.Unmarshal(body, &resps))
+		require.Equal(t, 2, len(resps))
+
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		rateLimit := response.NewRateLimitError("", nil).Code
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrCode(t, body, rateLimit)
+		body = doRPCCallOverHTTP(batch, httpSrv.URL, t)
+		checkErrCode(t, body, rateLimit)
+		body = doRPCCallOverWS(fmt.Sprintf(rpcCall, "getblockcount"), httpSrv.URL, t)
+		checkErrCode(t, body, rateLimit)
+	})
+}
@@ -2,12 +2,71 @@ package server
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Metrics used in monitoring service.
-var rpcCounter = map[string]prometheus.Counter{}
+var (
+	rpcCounter = map[string]prometheus.Counter{}
+
+	rpcRejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of rejected rpc requests",
+			Name:      "rpc_rejected_requests",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+
+	rpcDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "RPC requests processing time (in seconds)",
+			Name:      "rpc_request_duration_seconds",
+			Namespace: "neogo",
+			Buckets:   []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"method"},
+	)
+
+	rpcErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of rpc requests that ended with an error",
+			Name:      "rpc_errors",
+			Namespace: "neogo",
+		},
+		[]string{"method", "code"},
+	)
+
+	wsClientsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of connected websocket clients",
+			Name:      "rpc_ws_clients",
+			Namespace: "neogo",
+		},
+	)
+
+	wsSubscriptionsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of active websocket subscriptions",
+			Name:      "rpc_ws_subscriptions",
+			Namespace: "neogo",
+		},
+		[]string{"event"},
+	)
+
+	wsDroppedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of events not delivered to slow websocket subscribers",
+			Name:      "rpc_ws_dropped_events",
+			Namespace: "neogo",
+		},
+		[]string{"event"},
+	)
+)
 
 func incCounter(name string) {
 	ctr, ok := rpcCounter[name]
@@ -16,6 +75,38 @@ func incCounter(name string) {
 	}
 }
 
+func incRejectedCounter(reason string) {
+	rpcRejectedCounter.WithLabelValues(reason).Inc()
+}
+
+// methodLabel returns metric label for the given method, all unknown methods
+// share the same label to keep metrics cardinality bounded.
+func methodLabel(method string) string {
+	if _, ok := rpcHandlers[method]; ok {
+		return method
+	}
+	if _, ok := rpcWsHandlers[method]; ok {
+		return method
+	}
+	return "unknown"
+}
+
+func observeDuration(method string, start time.Time) {
+	rpcDuration.WithLabelValues(methodLabel(method)).Observe(time.Since(start).Seconds())
+}
+
+func incErrorCounter(method string, err *response.Error) {
+	rpcErrorCounter.WithLabelValues(methodLabel(method), strconv.FormatInt(err.Code, 10)).Inc()
+}
+
+func addSubscriptionsGauge(event response.EventID, delta float64) {
+	wsSubscriptionsGauge.WithLabelValues(event.String()).Add(delta)
+}
+
+func incDroppedCounter(event response.EventID) {
+	wsDroppedCounter.WithLabelValues(event.String()).Inc()
+}
+
 func init() {
 	for call := range rpcHandlers {
 		ctr := prometheus.NewCounter(
@@ -28,4 +119,12 @@ func init() {
 		prometheus.MustRegister(ctr)
 		rpcCounter[call] = ctr
 	}
+	prometheus.MustRegister(
+		rpcRejectedCounter,
+		rpcDuration,
+		rpcErrorCounter,
+		wsClientsGauge,
+		wsSubscriptionsGauge,
+		wsDroppedCounter,
+	)
 }
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodLabel(t *testing.T) {
+	require.Equal(t, "getblockcount", methodLabel("getblockcount"))
+	require.Equal(t, "subscribe", methodLabel("subscribe"))
+	require.Equal(t, "unknown", methodLabel("nosuchmethod"))
+}
+
+func TestMetrics(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	invalidParams := strconv.FormatInt(response.ErrInvalidParams.Code, 10)
+	notFound := strconv.FormatInt(response.NewMethodNotFoundError("", nil).Code, 10)
+	errors := func(method, code string) float64 {
+		return testutil.ToFloat64(rpcErrorCounter.WithLabelValues(method, code))
+	}
+	subscriptions := func() float64 {
+		return testutil.ToFloat64(wsSubscriptionsGauge.WithLabelValues(response.MempoolTransactionRemovedEventID.String()))
+	}
+
+	badParams, unknown := errors("getblockhash", invalidParams), errors("unknown", notFound)
+	resp := callWSGetRaw(t, c, `{"jsonrpc": "2.0", "id": 1, "method": "getblockhash", "params": ["notanumber"]}`, respMsgs)
+	require.NotNil(t, resp.Error)
+	resp = callWSGetRaw(t, c, `{"jsonrpc": "2.0", "id": 1, "method": "nosuchmethod", "params": []}`, respMsgs)
+	require.NotNil(t, resp.Error)
+	resp = callWSGetRaw(t, c, `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`, respMsgs)
+	require.Nil(t, resp.Error)
+	require.Equal(t, badParams+1, errors("getblockhash", invalidParams))
+	require.Equal(t, unknown+1, errors("unknown", notFound))
+
+	subs := subscriptions()
+	id := callSubscribe(t, c, respMsgs, `["mempool_transaction_removed"]`)
+	require.Equal(t, subs+1, subscriptions())
+	callUnsubscribe(t, c, respMsgs, id)
+	require.Equal(t, subs, subscriptions())
+
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
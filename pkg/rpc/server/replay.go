@@ -0,0 +1,347 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"go.uber.org/zap"
+)
+
+type (
+	// replay is a state of subscription that replays historic events before
+	// switching to live ones. Live events are not sent to the subscriber
+	// while replay is in progress, they're kept in pending list along with
+	// the index of the block they belong to (block events are used as
+	// markers to find it). When historic events are replayed up to the
+	// last seen marker, pending events for subsequent blocks are sent and
+	// subscription switches to live mode. If there are too many pending
+	// events, they're dropped and the subscriber gets event_missed
+	// notification instead.
+	replay struct {
+		lock       sync.Mutex
+		pending    []pendingEvent
+		maxPending int
+		overflow   bool
+		marker     uint32
+		hasMark    bool
+		flushing   bool
+		replayed   uint32
+		markerCh   chan struct{}
+		start      chan struct{}
+		stop       chan struct{}
+	}
+
+	// pendingEvent is a live event received during replay. Index is zero
+	// for events received before the first block marker (they belong to
+	// blocks that are replayed anyway).
+	pendingEvent struct {
+		index uint32
+		msg   *websocket.PreparedMessage
+	}
+)
+
+func newReplay(maxPending int) *replay {
+	return &replay{
+		maxPending: maxPending,
+		markerCh:   make(chan struct{}, 1),
+		start:      make(chan struct{}),
+		stop:       make(chan struct{}),
+	}
+}
+
+// add adds live event to the pending list, blockIndex is only specified for
+// block events.
+func (r *replay) add(msg *websocket.PreparedMessage, blockIndex uint32) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if blockIndex == 0 && r.hasMark {
+		blockIndex = r.marker + 1
+	}
+	if len(r.pending) >= r.maxPending {
+		r.pending = nil
+		r.overflow = true
+	}
+	r.pending = append(r.pending, pendingEvent{index: blockIndex, msg: msg})
+}
+
+// setMarker notes that all live events for the block with the given index
+// are processed.
+func (r *replay) setMarker(index uint32) {
+	r.lock.Lock()
+	r.marker = index
+	r.hasMark = true
+	r.lock.Unlock()
+	select {
+	case r.markerCh <- struct{}{}:
+	default:
+	}
+}
+
+// takePending returns pending events for blocks following the replayed one
+// and clears the list. It returns false if there is no marker yet or if
+// there are more blocks to replay. Once it returns true all subsequent
+// events are returned irrespective of replayed value. Returned events are
+// preceded by the missed one if some events were dropped.
+func (r *replay) takePending(replayed uint32, missed *websocket.PreparedMessage) ([]*websocket.PreparedMessage, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.flushing {
+		replayed = r.replayed
+	} else if !r.hasMark || r.marker > replayed {
+		return nil, false
+	}
+	r.flushing = true
+	r.replayed = replayed
+	var msgs []*websocket.PreparedMessage
+	if r.overflow {
+		r.overflow = false
+		msgs = append(msgs, missed)
+	}
+	for _, e := range r.pending {
+		if e.index > replayed {
+			msgs = append(msgs, e.msg)
+		}
+	}
+	r.pending = nil
+	return msgs, true
+}
+
+// takeOverflow returns true (resetting the flag) if pending events were
+// dropped.
+func (r *replay) takeOverflow() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	overflow := r.overflow
+	r.overflow = false
+	return overflow
+}
+
+// target returns the index of the last block that needs to be replayed now.
+func (r *replay) target(height uint32) uint32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.hasMark {
+		return r.marker
+	}
+	return height
+}
+
+// startReplay sets up replay for the given subscription feed, the caller
+// is responsible for subscribing to block events (they're used as markers).
+// It's supposed to be called with s.subsLock taken by the caller. Replay
+// actually starts after subscription response is sent to the client (see
+// startQueuedReplays).
+func (s *Server) startReplay(sub *subscriber, id int, from uint32) {
+	r := newReplay(s.config.MaxReplayPending)
+	sub.feeds[id].replay = r
+	sub.replayQueue = append(sub.replayQueue, r)
+	go s.replayEvents(sub, sub.feeds[id], r, from)
+}
+
+// startQueuedReplays starts replays set up by the request that is already
+// answered. It's called from the websocket reader routine only.
+func (sub *subscriber) startQueuedReplays() {
+	for _, r := range sub.replayQueue {
+		close(r.start)
+	}
+	sub.replayQueue = nil
+}
+
+// stopReplay cancels replay of the given feed if it's in progress, it
+// returns true in this case and the caller is responsible for unsubscribing
+// from block events then. It's supposed to be called with s.subsLock taken
+// by the caller.
+func (s *Server) stopReplay(f *feed) bool {
+	if f.replay == nil {
+		return false
+	}
+	close(f.replay.stop)
+	f.replay = nil
+	return true
+}
+
+// replayEvents sends historic events starting from the given block to the
+// subscriber and then switches the feed to live events. If there are more
+// than MaxReplayDepth blocks to replay or pending live events overflow,
+// the subscriber gets event_missed notification and replay is cut short.
+func (s *Server) replayEvents(sub *subscriber, f feed, r *replay, from uint32) {
+	// Genesis block events are never announced.
+	if from == 0 {
+		from = 1
+	}
+	var (
+		next = from
+		msgs []*websocket.PreparedMessage
+		ok   bool
+	)
+	select {
+	case <-r.start:
+	case <-r.stop:
+		return
+	case <-s.shutdown:
+		return
+	}
+	missed, err := newMissedEventMessage()
+	if err != nil {
+		return
+	}
+	send := func(msgs []*websocket.PreparedMessage) bool {
+		for _, msg := range msgs {
+			select {
+			case sub.writer <- msg:
+			case <-r.stop:
+				return false
+			case <-s.shutdown:
+				return false
+			}
+		}
+		return true
+	}
+	if last := r.target(s.chain.BlockHeight()); last >= from && last-from >= uint32(s.config.MaxReplayDepth) {
+		// Client is notified and gets live events only.
+		if !send([]*websocket.PreparedMessage{missed}) {
+			return
+		}
+		next = last + 1
+	}
+	for !ok {
+		for last := r.target(s.chain.BlockHeight()); next <= last; next++ {
+			var (
+				msgs []*websocket.PreparedMessage
+				err  error
+			)
+			if r.takeOverflow() {
+				// Pending events are lost, so replay is stopped here.
+				msgs = []*websocket.PreparedMessage{missed}
+				next = last
+			} else if msgs, err = s.blockEventMessages(&f, next); err != nil {
+				// Client is notified and gets live events only.
+				s.log.Error("failed to replay events", zap.Uint32("block", next), zap.Error(err))
+				msgs = []*websocket.PreparedMessage{missed}
+				next = last
+			}
+			if !send(msgs) {
+				return
+			}
+		}
+		msgs, ok = r.takePending(next-1, missed)
+		if !ok && next > r.target(s.chain.BlockHeight()) {
+			// Everything is replayed, wait for the next block.
+			select {
+			case <-r.markerCh:
+			case <-r.stop:
+				return
+			case <-s.shutdown:
+				return
+			}
+		}
+	}
+	// All pending events now belong to blocks that are not replayed, live
+	// events are still collected while they're sent, so switching happens
+	// with the lock held and nothing pending.
+	for {
+		if !send(msgs) {
+			return
+		}
+		s.subsLock.Lock()
+		select {
+		case <-r.stop:
+			s.subsLock.Unlock()
+			return
+		default:
+		}
+		msgs, _ = r.takePending(next-1, missed)
+		if len(msgs) == 0 {
+			for i := range sub.feeds {
+				if sub.feeds[i].replay == r {
+					sub.feeds[i].replay = nil
+				}
+			}
+			s.subsLock.Unlock()
+			s.subsCounterLock.Lock()
+			s.unsubscribeFromChannel(response.BlockEventID)
+			s.subsCounterLock.Unlock()
+			return
+		}
+		s.subsLock.Unlock()
+	}
+}
+
+// blockEventMessages returns messages for all events generated by the block
+// with the given index that match the feed in the same order they're
+// announced.
+func (s *Server) blockEventMessages(f *feed, index uint32) ([]*websocket.PreparedMessage, error) {
+	b, err := s.chain.GetBlock(s.chain.GetHeaderHash(int(index)))
+	if err != nil {
+		return nil, err
+	}
+	var resps []*response.Notification
+	add := func(payload interface{}) {
+		resp := &response.Notification{
+			JSONRPC: request.JSONRPCVersion,
+			Event:   f.event,
+			Payload: []interface{}{payload},
+		}
+		if f.Matches(resp) {
+			resps = append(resps, resp)
+		}
+	}
+	switch f.event {
+	case response.BlockEventID:
+		add(b)
+	case response.TransactionEventID:
+		for _, tx := range b.Transactions {
+			add(tx)
+		}
+	case response.NotificationEventID, response.ExecutionEventID:
+		aers, err := s.blockAppExecResults(b)
+		if err != nil {
+			return nil, err
+		}
+		for _, aer := range aers {
+			if f.event == response.ExecutionEventID {
+				add(result.NewApplicationLog(aer))
+				continue
+			}
+			// Only notifications of successful transactions are
+			// announced, but onPersist ones are always sent.
+			if aer.Trigger == trigger.System || aer.VMState == vm.HaltState {
+				for j := range aer.Events {
+					add(result.StateEventToResultNotification(aer.Events[j]))
+				}
+			}
+		}
+	}
+	msgs := make([]*websocket.PreparedMessage, 0, len(resps))
+	for _, resp := range resps {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := websocket.NewPreparedMessage(websocket.TextMessage, data)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// newMissedEventMessage creates a message for event_missed notification.
+func newMissedEventMessage() (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(response.Notification{
+		JSONRPC: request.JSONRPCVersion,
+		Event:   response.MissedEventID,
+		Payload: make([]interface{}, 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"math/big"
 	"net"
@@ -19,7 +22,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -30,7 +36,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"go.uber.org/zap"
 )
 
@@ -46,17 +55,31 @@ type (
 		https      *http.Server
 		shutdown   chan struct{}
 
-		subsLock         sync.RWMutex
-		subscribers      map[*subscriber]bool
-		subsGroup        sync.WaitGroup
+		subsLock    sync.RWMutex
+		subscribers map[*subscriber]bool
+		subsGroup   sync.WaitGroup
+		// subsCounterLock protects subscription counters below and
+		// chain (un)subscriptions, it's separate from subsLock to not
+		// block event processing while chain event dispatcher is busy.
+		subsCounterLock  sync.Mutex
 		blockSubs        int
 		executionSubs    int
 		notificationSubs int
 		transactionSubs  int
+		mempoolSubs      int
 		blockCh          chan *block.Block
 		executionCh      chan *state.AppExecResult
 		notificationCh   chan *state.NotificationEvent
 		transactionCh    chan *transaction.Transaction
+		mempoolCh        chan mempool.Event
+
+		sessionsLock sync.Mutex
+		sessions     map[string]*session
+
+		allowedMethods map[string]bool
+		deniedMethods  map[string]bool
+		limiter        *rateLimiter
+		auth           *authenticator
 	}
 )
 
@@ -77,33 +100,63 @@ const (
 	// treated like subscriber, so technically it's a limit on websocket
 	// connections.
 	maxSubscribers = 64
+
+	// Default maximum number of items returned by findstates.
+	defaultMaxFindResultItems = 100
+
+	// Default maximum number of requests in a single batch.
+	defaultMaxBatchSize = 100
+
+	// Default maximum number of blocks replayed for a subscription.
+	defaultMaxReplayDepth = 10000
+
+	// Default maximum number of live events kept during replay.
+	defaultMaxReplayPending = 10000
+
+	// Maximum number of transfers returned by getnep5transfers per page.
+	maxNEP5TransfersLimit = 1000
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
-	"getapplicationlog":    (*Server).getApplicationLog,
-	"getbestblockhash":     (*Server).getBestBlockHash,
-	"getblock":             (*Server).getBlock,
-	"getblockcount":        (*Server).getBlockCount,
-	"getblockhash":         (*Server).getBlockHash,
-	"getblockheader":       (*Server).getBlockHeader,
-	"getblocksysfee":       (*Server).getBlockSysFee,
-	"getconnectioncount":   (*Server).getConnectionCount,
-	"getcontractstate":     (*Server).getContractState,
-	"getnep5balances":      (*Server).getNEP5Balances,
-	"getnep5transfers":     (*Server).getNEP5Transfers,
-	"getpeers":             (*Server).getPeers,
-	"getrawmempool":        (*Server).getRawMempool,
-	"getrawtransaction":    (*Server).getrawtransaction,
-	"getstorage":           (*Server).getStorage,
-	"gettransactionheight": (*Server).getTransactionHeight,
-	"getunclaimedgas":      (*Server).getUnclaimedGas,
-	"getvalidators":        (*Server).getValidators,
-	"getversion":           (*Server).getVersion,
-	"invokefunction":       (*Server).invokeFunction,
-	"invokescript":         (*Server).invokescript,
-	"sendrawtransaction":   (*Server).sendrawtransaction,
-	"submitblock":          (*Server).submitBlock,
-	"validateaddress":      (*Server).validateAddress,
+	"calculatenetworkfee":    (*Server).calculateNetworkFee,
+	"findstates":             (*Server).findStates,
+	"getapplicationlog":      (*Server).getApplicationLog,
+	"getbestblockhash":       (*Server).getBestBlockHash,
+	"getblock":               (*Server).getBlock,
+	"getblockcount":          (*Server).getBlockCount,
+	"getblockhash":           (*Server).getBlockHash,
+	"getblockheader":         (*Server).getBlockHeader,
+	"getblocknotifications":  (*Server).getBlockNotifications,
+	"getblocksysfee":         (*Server).getBlockSysFee,
+	"getcandidates":          (*Server).getCandidates,
+	"getcommittee":           (*Server).getCommittee,
+	"getconnectioncount":     (*Server).getConnectionCount,
+	"getcontractstate":       (*Server).getContractState,
+	"getnativecontracts":     (*Server).getNativeContracts,
+	"getnep5balances":        (*Server).getNEP5Balances,
+	"getnep5transfers":       (*Server).getNEP5Transfers,
+	"getnextblockvalidators": (*Server).getNextBlockValidators,
+	"getpeers":               (*Server).getPeers,
+	"getproof":               (*Server).getProof,
+	"getrawmempool":          (*Server).getRawMempool,
+	"getrawtransaction":      (*Server).getrawtransaction,
+	"getstateheight":         (*Server).getStateHeight,
+	"getstateroot":           (*Server).getStateRoot,
+	"getstorage":             (*Server).getStorage,
+	"gettransactionheight":   (*Server).getTransactionHeight,
+	"getunclaimedgas":        (*Server).getUnclaimedGas,
+	"getvalidators":          (*Server).getValidators,
+	"getversion":             (*Server).getVersion,
+	"invokecontractverify":   (*Server).invokeContractVerify,
+	"invokefunction":         (*Server).invokeFunction,
+	"invokescript":           (*Server).invokescript,
+	"sendrawtransaction":     (*Server).sendrawtransaction,
+	"submitblock":            (*Server).submitBlock,
+	"testsendrawtransaction": (*Server).testSendRawTransaction,
+	"terminatesession":       (*Server).terminateSession,
+	"traverseiterator":       (*Server).traverseIterator,
+	"validateaddress":        (*Server).validateAddress,
+	"verifyproof":            (*Server).verifyProof,
 }
 
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
@@ -125,6 +178,31 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		Addr: conf.Address + ":" + strconv.FormatUint(uint64(conf.Port), 10),
 	}
 
+	if conf.MaxFindResultItems <= 0 {
+		conf.MaxFindResultItems = defaultMaxFindResultItems
+	}
+	if conf.MaxBatchSize <= 0 {
+		conf.MaxBatchSize = defaultMaxBatchSize
+	}
+	if conf.SessionExpirationTime <= 0 {
+		conf.SessionExpirationTime = defaultSessionExpirationTime
+	}
+	if conf.SessionPoolSize <= 0 {
+		conf.SessionPoolSize = defaultSessionPoolSize
+	}
+	if conf.MaxIteratorResultItems <= 0 {
+		conf.MaxIteratorResultItems = defaultMaxIteratorResultItems
+	}
+	if conf.MaxRequestBodyBytes <= 0 {
+		conf.MaxRequestBodyBytes = defaultMaxRequestBodyBytes
+	}
+	if conf.MaxReplayDepth <= 0 {
+		conf.MaxReplayDepth = defaultMaxReplayDepth
+	}
+	if conf.MaxReplayPending <= 0 {
+		conf.MaxReplayPending = defaultMaxReplayPending
+	}
+
 	var tlsServer *http.Server
 	if cfg := conf.TLSConfig; cfg.Enabled {
 		tlsServer = &http.Server{
@@ -148,6 +226,14 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		executionCh:    make(chan *state.AppExecResult),
 		notificationCh: make(chan *state.NotificationEvent),
 		transactionCh:  make(chan *transaction.Transaction),
+		mempoolCh:      make(chan mempool.Event),
+
+		sessions: make(map[string]*session),
+
+		allowedMethods: makeMethodSet(conf.AllowedMethods),
+		deniedMethods:  makeMethodSet(conf.DeniedMethods),
+		limiter:        newRateLimiter(conf.RateLimit, conf.RateLimitBurst),
+		auth:           newAuthenticator(conf.Auth),
 	}
 }
 
@@ -200,6 +286,8 @@ func (s *Server) Shutdown() error {
 	// Wait for handleSubEvents to finish.
 	<-s.executionCh
 
+	s.terminateSessions()
+
 	if err == nil {
 		return httpsErr
 	}
@@ -209,6 +297,12 @@ func (s *Server) Shutdown() error {
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	req := request.NewIn()
 
+	authenticated, rErr := s.authenticate(httpRequest)
+	if rErr != nil {
+		s.writeHTTPErrorResponse(req, w, rErr)
+		return
+	}
+
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
 		// s.subscribers modification 20 lines below, but it's tiny
@@ -230,12 +324,13 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 			s.log.Info("websocket connection upgrade failed", zap.Error(err))
 			return
 		}
-		resChan := make(chan response.Raw)
+		resChan := make(chan interface{})
 		subChan := make(chan *websocket.PreparedMessage, notificationBufSize)
-		subscr := &subscriber{writer: subChan, ws: ws}
+		subscr := &subscriber{writer: subChan, ws: ws, authenticated: authenticated}
 		s.subsLock.Lock()
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
+		wsClientsGauge.Inc()
 		go s.handleWsWrites(ws, resChan, subChan)
 		s.handleWsReads(ws, resChan, subscr)
 		return
@@ -252,20 +347,85 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	err := req.DecodeData(httpRequest.Body)
+	body, tooBig, err := readRequestBody(httpRequest.Body, s.config.MaxRequestBodyBytes)
+	if tooBig {
+		incRejectedCounter(rejectedByBodySize)
+		s.writeHTTPErrorResponse(req, w, response.NewInvalidRequestError(
+			fmt.Sprintf("request body is too big, maximum is %d bytes", s.config.MaxRequestBodyBytes), nil))
+		return
+	}
+	r := request.NewRequest()
+	if err == nil {
+		err = r.DecodeData(ioutil.NopCloser(bytes.NewReader(body)))
+	}
 	if err != nil {
 		s.writeHTTPErrorResponse(req, w, response.NewParseError("Problem parsing JSON-RPC request body", err))
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
-	s.writeHTTPServerResponse(req, w, resp)
+	if r.In != nil {
+		if rErr := s.checkRateLimit(clientIP(httpRequest.RemoteAddr), 1); rErr != nil {
+			s.writeHTTPErrorResponse(r.In, w, rErr)
+			return
+		}
+		resp := s.handleRequest(r.In, nil, authenticated)
+		s.writeHTTPServerResponse(r.In, w, resp)
+		return
+	}
+	if rErr := s.checkRateLimit(clientIP(httpRequest.RemoteAddr), len(r.Batch)); rErr != nil {
+		s.writeHTTPErrorResponse(req, w, rErr)
+		return
+	}
+	resps, rErr := s.handleBatch(r.Batch, nil, authenticated)
+	if rErr != nil {
+		s.writeHTTPErrorResponse(req, w, rErr)
+		return
+	}
+	s.writeHTTPBatchResponse(w, resps)
+}
+
+// handleBatch processes all requests of the given batch in order. It only
+// returns an error if the batch can't be processed at all, errors of
+// particular requests are returned as a part of the batch response.
+func (s *Server) handleBatch(batch request.Batch, sub *subscriber, authenticated bool) (response.Batch, *response.Error) {
+	if len(batch) == 0 {
+		return nil, response.NewInvalidRequestError("empty batch", nil)
+	}
+	if len(batch) > s.config.MaxBatchSize {
+		return nil, response.NewInvalidRequestError(
+			fmt.Sprintf("batch is too big: %d requests, maximum is %d", len(batch), s.config.MaxBatchSize), nil)
+	}
+	resps := make(response.Batch, len(batch))
+	for i := range batch {
+		req := &batch[i]
+		if req.JSONRPC != request.JSONRPCVersion {
+			resps[i] = s.packResponseToRaw(req, nil, response.NewInvalidRequestError(
+				fmt.Sprintf("invalid version, expected 2.0 got: '%s'", req.JSONRPC), nil))
+			resps[i].JSONRPC = request.JSONRPCVersion
+		} else {
+			resps[i] = s.handleRequest(req, sub, authenticated)
+		}
+		if resps[i].Error != nil {
+			s.logRequestError(req, resps[i].Error)
+		}
+	}
+	return resps, nil
 }
 
-func (s *Server) handleRequest(req *request.In, sub *subscriber) response.Raw {
+func (s *Server) handleRequest(req *request.In, sub *subscriber, authenticated bool) response.Raw {
 	var res interface{}
 	var resErr *response.Error
 
+	defer observeDuration(req.Method, time.Now())
+	if !s.isMethodAllowed(req.Method) {
+		incRejectedCounter(rejectedByMethod)
+		return s.packResponseToRaw(req, nil, response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' is disabled", req.Method), nil))
+	}
+	if !s.isMethodAvailable(req.Method, authenticated) {
+		incRejectedCounter(rejectedByAuth)
+		return s.packResponseToRaw(req, nil, response.NewUnauthorizedError(fmt.Sprintf("Method '%s' requires authentication", req.Method), nil))
+	}
+
 	reqParams, err := req.Params()
 	if err != nil {
 		return s.packResponseToRaw(req, nil, response.NewInvalidParamsError("Problem parsing request parameters", err))
@@ -290,7 +450,9 @@ func (s *Server) handleRequest(req *request.In, sub *subscriber) response.Raw {
 	return s.packResponseToRaw(req, res, resErr)
 }
 
-func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.Raw, subChan <-chan *websocket.PreparedMessage) {
+// handleWsWrites sends responses (either response.Raw or response.Batch) and
+// events to the websocket client.
+func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan interface{}, subChan <-chan *websocket.PreparedMessage) {
 	pingTicker := time.NewTicker(wsPingPeriod)
 eventloop:
 	for {
@@ -337,36 +499,66 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.Raw, subscr *subscriber) {
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- interface{}, subscr *subscriber) {
 	ws.SetReadLimit(wsReadLimit)
 	ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
 requestloop:
 	for {
-		req := new(request.In)
+		var res interface{}
+		req := request.NewRequest()
 		err := ws.ReadJSON(req)
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
-		if res.Error != nil {
-			s.logRequestError(req, res.Error)
+		if rErr := s.checkRateLimit(clientIP(ws.RemoteAddr().String()), len(req.Batch)); rErr != nil {
+			in := req.In
+			if in == nil {
+				in = request.NewIn()
+			}
+			res = s.packResponseToRaw(in, nil, rErr)
+		} else if req.In != nil {
+			resp := s.handleRequest(req.In, subscr, subscr.authenticated)
+			if resp.Error != nil {
+				s.logRequestError(req.In, resp.Error)
+			}
+			res = resp
+		} else {
+			resps, rErr := s.handleBatch(req.Batch, subscr, subscr.authenticated)
+			if rErr != nil {
+				res = s.packResponseToRaw(request.NewIn(), nil, rErr)
+			} else {
+				res = resps
+			}
 		}
 		select {
 		case <-s.shutdown:
 			break requestloop
 		case resChan <- res:
 		}
+		// Response is written before any replayed event this way.
+		subscr.startQueuedReplays()
 
 	}
+	var events []response.EventID
 	s.subsLock.Lock()
 	delete(s.subscribers, subscr)
-	for _, e := range subscr.feeds {
-		if e.event != response.InvalidEventID {
-			s.unsubscribeFromChannel(e.event)
+	for i := range subscr.feeds {
+		if subscr.feeds[i].event != response.InvalidEventID {
+			if s.stopReplay(&subscr.feeds[i]) {
+				events = append(events, response.BlockEventID)
+			}
+			events = append(events, subscr.feeds[i].event)
+			addSubscriptionsGauge(subscr.feeds[i].event, -1)
 		}
 	}
 	s.subsLock.Unlock()
+	wsClientsGauge.Dec()
+	s.subsCounterLock.Lock()
+	for _, e := range events {
+		s.unsubscribeFromChannel(e)
+	}
+	s.subsCounterLock.Unlock()
 	close(resChan)
 	ws.Close()
 }
@@ -464,9 +656,22 @@ func (s *Server) getPeers(_ request.Params) (interface{}, *response.Error) {
 
 func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response.Error) {
 	verbose := reqParams.Value(0).GetBoolean()
+	details := reqParams.Value(1).GetBoolean()
 	mp := s.chain.GetMemPool()
+	txs := mp.GetVerifiedTransactions()
+	if details {
+		// There are no unverified transactions in the pool.
+		res := result.RawMempoolDetails{
+			Height:       s.chain.BlockHeight(),
+			Transactions: make([]result.MempoolTransaction, 0, len(txs)),
+		}
+		for _, tx := range txs {
+			res.Transactions = append(res.Transactions, result.NewMempoolTransaction(tx, true))
+		}
+		return res, nil
+	}
 	hashList := make([]util.Uint256, 0)
-	for _, item := range mp.GetVerifiedTransactions() {
+	for _, item := range txs {
 		hashList = append(hashList, item.Hash())
 	}
 	if !verbose {
@@ -501,6 +706,83 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 	return result.NewApplicationLog(appExecResult), nil
 }
 
+// getBlockNotifications returns all notifications emitted by the given block
+// (during its persisting and by its successful transactions) in the same
+// order they're generated, optionally filtered.
+func (s *Server) getBlockNotifications(reqParams request.Params) (interface{}, *response.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	var filters []interface{}
+	if p := reqParams.Value(1); p != nil && p.Value != nil {
+		var err error
+		filters, err = parseFilters(response.NotificationEventID, p)
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	b, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, response.NewRPCError("Unknown block", "", err)
+	}
+	aers, err := s.blockAppExecResults(b)
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get block execution results", err)
+	}
+	res := &result.BlockNotifications{
+		Hash:          b.Hash(),
+		Index:         b.Index,
+		Notifications: []result.ContainedNotificationEvent{},
+	}
+	for _, aer := range aers {
+		// Notifications of failed transactions are discarded.
+		if aer.Trigger != trigger.System && aer.VMState != vm.HaltState {
+			continue
+		}
+		for j := range aer.Events {
+			ne := result.StateEventToResultNotification(aer.Events[j])
+			if !filtersMatch(filters, ne) {
+				continue
+			}
+			res.Notifications = append(res.Notifications, result.ContainedNotificationEvent{
+				Container:         aer.TxHash,
+				NotificationEvent: ne,
+			})
+		}
+	}
+	return res, nil
+}
+
+// blockAppExecResults returns execution results of the block, the first one
+// is the result of block persisting (except for the genesis block that is
+// not executed this way), followed by the results of its transactions in the
+// block order.
+func (s *Server) blockAppExecResults(b *block.Block) ([]*state.AppExecResult, error) {
+	hashes := make([]util.Uint256, 0, 1+len(b.Transactions))
+	if b.Index > 0 {
+		hashes = append(hashes, b.Hash())
+	}
+	for _, tx := range b.Transactions {
+		hashes = append(hashes, tx.Hash())
+	}
+	aers := make([]*state.AppExecResult, 0, len(hashes))
+	for _, h := range hashes {
+		aer, err := s.chain.GetAppExecResult(h)
+		if err != nil {
+			return nil, err
+		}
+		aers = append(aers, aer)
+	}
+	return aers, nil
+}
+
+// getNativeContracts returns the list of native contracts along with their
+// manifests and method prices.
+func (s *Server) getNativeContracts(_ request.Params) (interface{}, *response.Error) {
+	return s.chain.GetNatives(), nil
+}
+
 func (s *Server) getNEP5Balances(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
@@ -519,7 +801,7 @@ func (s *Server) getNEP5Balances(ps request.Params) (interface{}, *response.Erro
 			if err != nil {
 				continue
 			}
-			amount := amountToString(&bal.Balance, dec.Value)
+			amount := util.BigFixedNToString(&bal.Balance, int(dec.Value))
 			bs.Balances = append(bs.Balances, result.NEP5Balance{
 				Asset:       dec.Hash,
 				Amount:      amount,
@@ -549,6 +831,39 @@ func getTimestamps(p1, p2 *request.Param) (uint64, uint64, error) {
 	return start, end, nil
 }
 
+// getLimitAndPage parses optional limit and page parameters, zero limit means
+// no limit.
+func getLimitAndPage(p1, p2 *request.Param) (int, int, error) {
+	var limit, page int
+	if p1 != nil {
+		val, err := p1.GetInt()
+		if err != nil {
+			return 0, 0, err
+		}
+		if val <= 0 || val > maxNEP5TransfersLimit {
+			return 0, 0, fmt.Errorf("limit should be in [1, %d] range", maxNEP5TransfersLimit)
+		}
+		limit = val
+	}
+	if p2 != nil {
+		val, err := p2.GetInt()
+		if err != nil {
+			return 0, 0, err
+		}
+		if val < 0 {
+			return 0, 0, errors.New("page can't be negative")
+		}
+		if p1 == nil {
+			return 0, 0, errors.New("page can't be used without limit")
+		}
+		if val > math.MaxInt32/limit {
+			return 0, 0, fmt.Errorf("page should be in [0, %d] range", math.MaxInt32/limit)
+		}
+		page = val
+	}
+	return limit, page, nil
+}
+
 func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
@@ -567,20 +882,33 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 		}
 	}
 
+	limit, page, err := getLimitAndPage(ps.Value(3), ps.Value(4))
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+
 	bs := &result.NEP5Transfers{
 		Address:  address.Uint160ToString(u),
 		Received: []result.NEP5Transfer{},
 		Sent:     []result.NEP5Transfer{},
 	}
 	cache := make(map[int32]decimals)
-	err = s.chain.ForEachNEP5Transfer(u, func(tr *state.NEP5Transfer) error {
-		if tr.Timestamp < start || tr.Timestamp > end {
-			return nil
+	var count, skip = 0, limit * page
+	err = s.chain.SeekNEP5Transfers(u, start, end, func(tr *state.NEP5Transfer) (bool, error) {
+		if limit != 0 && count >= limit {
+			return false, nil
 		}
+		// Transfers that can't be decoded are not counted for paging
+		// either, otherwise pages could overlap.
 		d, err := s.getDecimals(tr.Asset, cache)
 		if err != nil {
-			return nil
+			return true, nil
 		}
+		if skip > 0 {
+			skip--
+			return true, nil
+		}
+		count++
 		transfer := result.NEP5Transfer{
 			Timestamp: tr.Timestamp,
 			Asset:     d.Hash,
@@ -588,20 +916,20 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 			TxHash:    tr.Tx,
 		}
 		if tr.Amount.Sign() > 0 { // token was received
-			transfer.Amount = amountToString(&tr.Amount, d.Value)
+			transfer.Amount = util.BigFixedNToString(&tr.Amount, int(d.Value))
 			if !tr.From.Equals(util.Uint160{}) {
 				transfer.Address = address.Uint160ToString(tr.From)
 			}
 			bs.Received = append(bs.Received, transfer)
-			return nil
+			return true, nil
 		}
 
-		transfer.Amount = amountToString(new(big.Int).Neg(&tr.Amount), d.Value)
+		transfer.Amount = util.BigFixedNToString(new(big.Int).Neg(&tr.Amount), int(d.Value))
 		if !tr.To.Equals(util.Uint160{}) {
 			transfer.Address = address.Uint160ToString(tr.To)
 		}
 		bs.Sent = append(bs.Sent, transfer)
-		return nil
+		return true, nil
 	})
 	if err != nil {
 		return nil, response.NewInternalServerError("invalid NEP5 transfer log", err)
@@ -609,19 +937,6 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 	return bs, nil
 }
 
-func amountToString(amount *big.Int, decimals int64) string {
-	if decimals == 0 {
-		return amount.String()
-	}
-	pow := int64(math.Pow10(int(decimals)))
-	q, r := new(big.Int).DivMod(amount, big.NewInt(pow), new(big.Int))
-	if r.Sign() == 0 {
-		return q.String()
-	}
-	fs := fmt.Sprintf("%%d.%%0%dd", decimals)
-	return fmt.Sprintf(fs, q, r)
-}
-
 // decimals represents decimals value for the contract with the specified scripthash.
 type decimals struct {
 	Hash  util.Uint160
@@ -649,7 +964,7 @@ func (s *Server) getDecimals(contractID int32, cache map[int32]decimals) (decima
 	if err != nil {
 		return decimals{}, fmt.Errorf("can't create script: %w", err)
 	}
-	res := s.runScriptInVM(script, nil)
+	res := s.runScriptInVM(script, nil, nil, false)
 	if res == nil || res.State != "HALT" || len(res.Stack) == 0 {
 		return decimals{}, errors.New("execution error : no result")
 	}
@@ -696,6 +1011,46 @@ func (s *Server) contractIDFromParam(param *request.Param) (int32, *response.Err
 	return result, nil
 }
 
+func (s *Server) getStateHeight(_ request.Params) (interface{}, *response.Error) {
+	return &result.StateHeight{
+		BlockHeight: s.chain.BlockHeight(),
+		StateHeight: s.chain.StateHeight(),
+	}, nil
+}
+
+func (s *Server) getStateRoot(ps request.Params) (interface{}, *response.Error) {
+	p := ps.Value(0)
+	if p == nil {
+		return nil, response.ErrInvalidParams
+	}
+	var index uint32
+	switch p.Type {
+	case request.NumberT:
+		num, err := p.GetInt()
+		if err != nil || num < 0 {
+			return nil, response.ErrInvalidParams
+		}
+		index = uint32(num)
+	case request.StringT:
+		h, err := p.GetUint256()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		hdr, err := s.chain.GetHeader(h)
+		if err != nil {
+			return nil, response.NewRPCError("unknown block", "", nil)
+		}
+		index = hdr.Index
+	default:
+		return nil, response.ErrInvalidParams
+	}
+	rt, err := s.chain.GetStateRoot(index)
+	if err != nil {
+		return nil, response.NewRPCError("Unknown state root", "", err)
+	}
+	return rt, nil
+}
+
 func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 	id, rErr := s.contractIDFromParam(ps.Value(0))
 	if rErr == response.ErrUnknown {
@@ -718,6 +1073,242 @@ func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 	return hex.EncodeToString(item.Value), nil
 }
 
+// calculateNetworkFee calculates network fee needed for the given transaction
+// to be accepted. Witnesses are taken into account if they're present in the
+// transaction, standard accounts need verification scripts to be specified and
+// contract-based ones may have invocation scripts pushing verify arguments.
+func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *response.Error) {
+	byteTx, err := reqParams.ValueWithType(0, request.StringT).GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	tx, err := transaction.NewTransactionFromBytes(s.network, byteTx)
+	if err != nil {
+		return nil, response.NewInvalidParamsError("can't decode transaction", err)
+	}
+	if len(tx.Scripts) > len(tx.Signers) {
+		return nil, response.NewInvalidParamsError("too many witnesses", nil)
+	}
+	// Signed part includes network magic which is not a part of transaction.
+	size := len(tx.GetSignedPart()) - 4 + io.GetVarSize(len(tx.Signers))
+	var netFee int64
+	for i, signer := range tx.Signers {
+		var w transaction.Witness
+		if i < len(tx.Scripts) {
+			w = tx.Scripts[i]
+		}
+		if len(w.VerificationScript) != 0 {
+			if w.ScriptHash() != signer.Account {
+				return nil, response.NewInvalidParamsError(fmt.Sprintf("witness %d doesn't match signer", i), nil)
+			}
+			fee, sizeDelta := core.CalculateNetworkFee(w.VerificationScript)
+			if sizeDelta != 0 {
+				netFee += fee
+				size += sizeDelta
+				continue
+			}
+		}
+		// Non-standard verification script or contract-based witness.
+		v, err := s.chain.GetTestVerificationVM(tx, signer.Account, &w)
+		if err != nil {
+			return nil, response.NewRPCError("Can't verify witness", fmt.Sprintf("signer %d: %s", i, err), err)
+		}
+		v.GasLimit = int64(s.config.MaxGasInvoke)
+		err = v.Run()
+		if err == nil {
+			if v.Estack().Len() != 1 {
+				err = errors.New("expected exactly one returned value")
+			} else if res, bErr := v.Estack().Pop().Item().TryBool(); bErr != nil || !res {
+				err = errors.New("verification returned false")
+			}
+		}
+		if err != nil {
+			return nil, response.NewRPCError("Witness verification failed", fmt.Sprintf("signer %d: %s", i, err), err)
+		}
+		netFee += v.GasConsumed()
+		size += io.GetVarSize(w.InvocationScript) + io.GetVarSize(w.VerificationScript)
+	}
+	netFee += int64(size) * s.chain.FeePerByte()
+	return &result.NetworkFee{Value: netFee}, nil
+}
+
+func (s *Server) findStates(ps request.Params) (interface{}, *response.Error) {
+	id, rErr := s.contractIDFromParam(ps.Value(0))
+	if rErr == response.ErrUnknown {
+		return nil, response.NewRPCError("Unknown contract", "", nil)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+	prefix, err := ps.Value(1).GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var start []byte
+	if p := ps.Value(2); p != nil {
+		start, err = p.GetBytesHex()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		if len(start) == 0 {
+			start = nil
+		} else if !bytes.HasPrefix(start, prefix) {
+			return nil, response.NewInvalidParamsError("start key doesn't match prefix", nil)
+		}
+	}
+	limit := s.config.MaxFindResultItems
+	if p := ps.Value(3); p != nil {
+		l, err := p.GetInt()
+		if err != nil || l < 0 {
+			return nil, response.ErrInvalidParams
+		}
+		if l != 0 && l < limit {
+			limit = l
+		}
+	}
+	if p := ps.Value(4); p != nil {
+		root, err := p.GetUint256()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		return s.findStatesMPT(root, id, prefix, start, limit)
+	}
+
+	// Current state is taken from the latest state root, MPT allows to
+	// start traversal from the given key, so that paging doesn't require
+	// all items with the prefix to be read.
+	sr, err := s.chain.GetStateRoot(s.chain.BlockHeight())
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to get current state root", err)
+	}
+	res, _, rErr := s.findStorageItems(sr.Root, id, prefix, start, limit)
+	return res, rErr
+}
+
+// findStorageItems returns no more than limit contract storage items with the
+// given prefix following start key from the MPT with the given root along
+// with their MPT keys.
+func (s *Server) findStorageItems(root util.Uint256, id int32, prefix, start []byte, limit int) (*result.FindStates, []storage.KeyValue, *response.Error) {
+	var from []byte
+	if start != nil {
+		from = makeStorageKey(id, start)
+	}
+	kvs, err := s.chain.FindStates(root, makeStorageKey(id, prefix), from, limit+1)
+	if err != nil {
+		return nil, nil, response.NewRPCError("failed to find states", err.Error(), err)
+	}
+	res := &result.FindStates{Results: []result.KeyValue{}}
+	if len(kvs) > limit {
+		kvs = kvs[:limit]
+		res.Truncated = true
+	}
+	for i := range kvs {
+		si := new(state.StorageItem)
+		r := io.NewBinReaderFromBuf(kvs[i].Value)
+		si.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, nil, response.NewInternalServerError("failed to decode storage item", r.Err)
+		}
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   kvs[i].Key[4:],
+			Value: si.Value,
+		})
+	}
+	return res, kvs, nil
+}
+
+// findStatesMPT performs findstates request against the MPT with the given root.
+func (s *Server) findStatesMPT(root util.Uint256, id int32, prefix, start []byte, limit int) (interface{}, *response.Error) {
+	res, kvs, rErr := s.findStorageItems(root, id, prefix, start, limit)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if len(kvs) == 0 {
+		return res, nil
+	}
+	getProof := func(key []byte) (*result.ProofWithKey, *response.Error) {
+		proof, err := s.chain.GetStateProof(root, key)
+		if err != nil {
+			return nil, response.NewInternalServerError("failed to get proof", err)
+		}
+		return &result.ProofWithKey{Key: key, Proof: proof}, nil
+	}
+	if res.FirstProof, rErr = getProof(kvs[0].Key); rErr != nil {
+		return nil, rErr
+	}
+	if len(kvs) > 1 {
+		if res.LastProof, rErr = getProof(kvs[len(kvs)-1].Key); rErr != nil {
+			return nil, rErr
+		}
+	} else {
+		res.LastProof = res.FirstProof
+	}
+	return res, nil
+}
+
+func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
+	root, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	sc, err := ps.Value(1).GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	key, err := ps.Value(2).GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	cs := s.chain.GetContractState(sc)
+	if cs == nil {
+		return nil, response.ErrInvalidParams
+	}
+	skey := makeStorageKey(cs.ID, key)
+	proof, err := s.chain.GetStateProof(root, skey)
+	return &result.GetProof{
+		Result: result.ProofWithKey{
+			Key:   skey,
+			Proof: proof,
+		},
+		Success: err == nil,
+	}, nil
+}
+
+func (s *Server) verifyProof(ps request.Params) (interface{}, *response.Error) {
+	root, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	proofStr, err := ps.Value(1).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var p result.ProofWithKey
+	if err := p.FromString(proofStr); err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	vp := new(result.VerifyProof)
+	val, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+	if ok {
+		var si state.StorageItem
+		r := io.NewBinReaderFromBuf(val)
+		si.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, response.NewInternalServerError("invalid item in trie", r.Err)
+		}
+		vp.Result = si.Value
+	}
+	return vp, nil
+}
+
+// makeStorageKey returns a key used to store contract storage item in the MPT.
+func makeStorageKey(id int32, key []byte) []byte {
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	return skey
+}
+
 func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *response.Error) {
 	var resultsErr *response.Error
 	var results interface{}
@@ -867,6 +1458,68 @@ func (s *Server) getValidators(_ request.Params) (interface{}, *response.Error)
 	return res, nil
 }
 
+// getCommittee returns the current NEO committee members.
+func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
+	committee, err := s.chain.GetCommittee()
+	if err != nil {
+		return nil, response.NewRPCError("can't get committee", "", err)
+	}
+	return committee, nil
+}
+
+// getNextBlockValidators returns validators of the next block with their
+// votes.
+func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *response.Error) {
+	validators, err := s.chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, response.NewRPCError("can't get next block validators", "", err)
+	}
+	enrollments, err := s.chain.GetEnrollments()
+	if err != nil {
+		return nil, response.NewRPCError("can't get enrollments", "", err)
+	}
+	var res = make([]result.Validator, 0, len(validators))
+	for _, v := range validators {
+		var votes int64
+		for _, e := range enrollments {
+			if e.Key.Equal(v) {
+				votes = e.Votes.Int64()
+				break
+			}
+		}
+		res = append(res, result.Validator{
+			PublicKey: *v,
+			Votes:     votes,
+			Active:    true,
+		})
+	}
+	return res, nil
+}
+
+// getCandidates returns all registered candidates with their votes, Active
+// flag is set for next block validators.
+func (s *Server) getCandidates(_ request.Params) (interface{}, *response.Error) {
+	var validators keys.PublicKeys
+
+	validators, err := s.chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, response.NewRPCError("can't get next block validators", "", err)
+	}
+	enrollments, err := s.chain.GetEnrollments()
+	if err != nil {
+		return nil, response.NewRPCError("can't get enrollments", "", err)
+	}
+	var res = make([]result.Validator, 0, len(enrollments))
+	for _, v := range enrollments {
+		res = append(res, result.Validator{
+			PublicKey: *v.Key,
+			Votes:     v.Votes.Int64(),
+			Active:    validators.Contains(v.Key),
+		})
+	}
+	return res, nil
+}
+
 // invokeFunction implements the `invokeFunction` RPC call.
 func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *response.Error) {
 	scriptHash, err := reqParams.ValueWithType(0, request.StringT).GetUint160FromHex()
@@ -875,6 +1528,20 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 	}
 	tx := &transaction.Transaction{}
 	checkWitnessHashesIndex := len(reqParams)
+	var trace bool
+	if checkWitnessHashesIndex > 5 {
+		trace = reqParams[5].GetBoolean()
+		checkWitnessHashesIndex = 5
+	}
+	var root *util.Uint256
+	if checkWitnessHashesIndex > 4 {
+		r, rErr := s.optionalStateRootFromParam(reqParams.Value(4))
+		if rErr != nil {
+			return nil, rErr
+		}
+		root = r
+		checkWitnessHashesIndex = 4
+	}
 	if checkWitnessHashesIndex > 3 {
 		signers, err := reqParams[3].GetSigners()
 		if err != nil {
@@ -891,7 +1558,7 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
 	tx.Script = script
-	return s.runScriptInVM(script, tx), nil
+	return s.registerSession(s.runScriptInVM(script, tx, root, trace))
 }
 
 // invokescript implements the `invokescript` RPC call.
@@ -916,24 +1583,149 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 	if len(tx.Signers) == 0 {
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.FeeOnly}}
 	}
+	var root *util.Uint256
+	if len(reqParams) > 2 {
+		r, rErr := s.optionalStateRootFromParam(reqParams.Value(2))
+		if rErr != nil {
+			return nil, rErr
+		}
+		root = r
+	}
+	trace := len(reqParams) > 3 && reqParams[3].GetBoolean()
 	tx.Script = script
-	return s.runScriptInVM(script, tx), nil
+	return s.registerSession(s.runScriptInVM(script, tx, root, trace))
+}
+
+// invokeContractVerify implements the `invokecontractverify` RPC call.
+func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *response.Error) {
+	scriptHash, err := reqParams.ValueWithType(0, request.StringT).GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var args []request.Param
+	if len(reqParams) > 1 {
+		args, err = reqParams[1].GetArray()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	invocation, err := request.CreateArgumentsScript(args)
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+
+	tx := &transaction.Transaction{Script: []byte{byte(opcode.RET)}}
+	if len(reqParams) > 2 {
+		signers, err := reqParams[2].GetSigners()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		tx.Signers = signers
+	}
+	if len(tx.Signers) == 0 {
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.FeeOnly}}
+	}
+	v, err := s.chain.GetTestVerificationVM(tx, scriptHash, &transaction.Witness{InvocationScript: invocation})
+	if err != nil {
+		return nil, response.NewRPCError("Can't run contract verification", err.Error(), err)
+	}
+	v.GasLimit = int64(s.config.MaxGasInvoke)
+	_ = v.Run()
+	return s.registerSession(&result.Invoke{
+		State:       v.State().String(),
+		GasConsumed: v.GasConsumed(),
+		Script:      hex.EncodeToString(invocation),
+		Stack:       v.Estack().ToArray(),
+	})
+}
+
+// optionalStateRootFromParam is similar to stateRootFromParam, but it allows
+// the parameter to be null (meaning current state) returning nil root then.
+func (s *Server) optionalStateRootFromParam(param *request.Param) (*util.Uint256, *response.Error) {
+	if param != nil && param.Value == nil {
+		return nil, nil
+	}
+	root, rErr := s.stateRootFromParam(param)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return &root, nil
+}
+
+// stateRootFromParam returns state root hash either specified directly by
+// the parameter or corresponding to the block index given in it.
+func (s *Server) stateRootFromParam(param *request.Param) (util.Uint256, *response.Error) {
+	if param == nil {
+		return util.Uint256{}, response.ErrInvalidParams
+	}
+	switch param.Type {
+	case request.StringT:
+		root, err := param.GetUint256()
+		if err != nil {
+			return util.Uint256{}, response.ErrInvalidParams
+		}
+		if err := s.checkStateRoot(root); err != nil {
+			return util.Uint256{}, response.NewRPCError("Unknown state root", "", err)
+		}
+		return root, nil
+	case request.NumberT:
+		num, rErr := s.blockHeightFromParam(param)
+		if rErr != nil {
+			return util.Uint256{}, rErr
+		}
+		rt, err := s.chain.GetStateRoot(uint32(num))
+		if err != nil {
+			return util.Uint256{}, response.NewRPCError("Unknown state root", "", err)
+		}
+		return rt.Root, nil
+	default:
+		return util.Uint256{}, response.ErrInvalidParams
+	}
+}
+
+// checkStateRoot checks that the root node of the MPT with the given root
+// hash can be loaded from the store, otherwise nothing can be read from this
+// trie.
+func (s *Server) checkStateRoot(root util.Uint256) error {
+	if root.Equals(util.Uint256{}) {
+		return errors.New("empty state root")
+	}
+	_, err := s.chain.FindStates(root, nil, nil, 1)
+	return err
 }
 
 // runScriptInVM runs given script in a new test VM and returns the invocation
-// result.
-func (s *Server) runScriptInVM(script []byte, tx *transaction.Transaction) *result.Invoke {
-	vm := s.chain.GetTestVM(tx)
-	vm.GasLimit = int64(s.config.MaxGasInvoke)
-	vm.LoadScriptWithFlags(script, smartcontract.All)
-	_ = vm.Run()
-	result := &result.Invoke{
-		State:       vm.State().String(),
-		GasConsumed: vm.GasConsumed(),
+// result. If root is not nil, contract storage state from the MPT with this
+// root is used instead of the current one. If trace is true, execution trace
+// is collected and returned as a part of the result.
+func (s *Server) runScriptInVM(script []byte, tx *transaction.Transaction, root *util.Uint256, trace bool) *result.Invoke {
+	if trace {
+		v, t := s.chain.TraceTestScript(tx, root, script, int64(s.config.MaxGasInvoke))
+		res := newInvokeResult(v, script)
+		res.Trace = result.NewTrace(t)
+		return res
+	}
+	var v *vm.VM
+	if root != nil {
+		v = s.chain.GetTestHistoricVM(tx, *root)
+	} else {
+		v = s.chain.GetTestVM(tx)
+	}
+	v.GasLimit = int64(s.config.MaxGasInvoke)
+	v.LoadScriptWithFlags(script, smartcontract.All)
+	_ = v.Run()
+	return newInvokeResult(v, script)
+}
+
+// newInvokeResult creates invocation result from the VM that has finished
+// execution of the given script.
+func newInvokeResult(v *vm.VM, script []byte) *result.Invoke {
+	return &result.Invoke{
+		State:       v.State().String(),
+		GasConsumed: v.GasConsumed(),
 		Script:      hex.EncodeToString(script),
-		Stack:       vm.Estack().ToArray(),
+		Stack:       v.Estack().ToArray(),
 	}
-	return result
 }
 
 // submitBlock broadcasts a raw block over the NEO network.
@@ -999,6 +1791,55 @@ func (s *Server) sendrawtransaction(reqParams request.Params) (interface{}, *res
 	return results, resultsErr
 }
 
+// testSendRawTransaction verifies the transaction against the current chain
+// state and mempool contents without adding it to the mempool and relaying.
+func (s *Server) testSendRawTransaction(reqParams request.Params) (interface{}, *response.Error) {
+	byteTx, err := reqParams.Value(0).GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	tx, err := transaction.NewTransactionFromBytes(s.network, byteTx)
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	res := &result.TxVerification{Hash: tx.Hash()}
+	err = s.chain.VerifyTxAgainstPool(tx)
+	if err == nil {
+		res.Valid = true
+		return res, nil
+	}
+	res.Message = err.Error()
+	var witnessErr *core.WitnessError
+	switch {
+	case errors.As(err, &witnessErr):
+		res.Reason = result.TxWitness
+		res.Signer = &witnessErr.Index
+	case errors.Is(err, core.ErrTxInvalidWitnessNum):
+		res.Reason = result.TxWitness
+	case errors.Is(err, core.ErrAlreadyExists):
+		res.Reason = result.TxAlreadyExists
+	case errors.Is(err, core.ErrMemPoolConflict):
+		res.Reason = result.TxConflict
+	case errors.Is(err, core.ErrTxExpired):
+		res.Reason = result.TxExpired
+	case errors.Is(err, core.ErrInsufficientFunds):
+		res.Reason = result.TxInsufficientFunds
+	case errors.Is(err, core.ErrInvalidAttribute):
+		res.Reason = result.TxInvalidAttribute
+	case errors.Is(err, core.ErrOOM):
+		res.Reason = result.TxOutOfMemory
+	case errors.Is(err, core.ErrPolicy):
+		res.Reason = result.TxPolicy
+	case errors.Is(err, core.ErrTxSmallNetworkFee):
+		res.Reason = result.TxSmallNetworkFee
+	case errors.Is(err, core.ErrTxTooBig):
+		res.Reason = result.TxTooBig
+	default:
+		res.Reason = result.TxUnknown
+	}
+	return res, nil
+}
+
 // subscribe handles subscription requests from websocket clients.
 func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
 	streamName, err := reqParams.Value(0).GetString()
@@ -1009,34 +1850,36 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 	if err != nil || event == response.MissedEventID {
 		return nil, response.ErrInvalidParams
 	}
-	// Optional filter.
-	var filter interface{}
-	if p := reqParams.Value(1); p != nil {
-		switch event {
-		case response.BlockEventID:
-			if p.Type != request.BlockFilterT {
-				return nil, response.ErrInvalidParams
-			}
-		case response.TransactionEventID:
-			if p.Type != request.TxFilterT {
-				return nil, response.ErrInvalidParams
-			}
-		case response.NotificationEventID:
-			if p.Type != request.NotificationFilterT {
-				return nil, response.ErrInvalidParams
-			}
-		case response.ExecutionEventID:
-			if p.Type != request.ExecutionFilterT {
-				return nil, response.ErrInvalidParams
-			}
+	// Optional filter or a list of alternative filters (can be null if
+	// block index is specified).
+	var filters []interface{}
+	if p := reqParams.Value(1); p != nil && p.Value != nil {
+		filters, err = parseFilters(event, p)
+		if err != nil {
+			return nil, response.ErrInvalidParams
 		}
-		filter = p.Value
+	}
+	// Optional block index to replay events from.
+	var (
+		from   uint32
+		replay bool
+	)
+	if p := reqParams.Value(2); p != nil {
+		num, err := p.GetInt()
+		if err != nil || num < 0 || num > int(s.chain.BlockHeight())+1 {
+			return nil, response.ErrInvalidParams
+		}
+		if event == response.MempoolTransactionAddedEventID || event == response.MempoolTransactionRemovedEventID {
+			// There is no history for mempool events.
+			return nil, response.ErrInvalidParams
+		}
+		from, replay = uint32(num), true
 	}
 
 	s.subsLock.Lock()
-	defer s.subsLock.Unlock()
 	select {
 	case <-s.shutdown:
+		s.subsLock.Unlock()
 		return nil, response.NewInternalServerError("server is shutting down", nil)
 	default:
 	}
@@ -1047,17 +1890,34 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 		}
 	}
 	if id == len(sub.feeds) {
+		s.subsLock.Unlock()
 		return nil, response.NewInternalServerError("maximum number of subscriptions is reached", nil)
 	}
 	sub.feeds[id].event = event
-	sub.feeds[id].filter = filter
-	s.subscribeToChannel(event)
+	sub.feeds[id].filters = filters
+	if replay {
+		s.startReplay(sub, id, from)
+	}
+	s.subsLock.Unlock()
+	addSubscriptionsGauge(event, 1)
+
+	s.subsCounterLock.Lock()
+	select {
+	case <-s.shutdown:
+	default:
+		s.subscribeToChannel(event)
+		if replay {
+			// Block events are needed as markers for replay.
+			s.subscribeToChannel(response.BlockEventID)
+		}
+	}
+	s.subsCounterLock.Unlock()
 	return strconv.FormatInt(int64(id), 10), nil
 }
 
 // subscribeToChannel subscribes RPC server to appropriate chain events if
-// it's not yet subscribed for them. It's supposed to be called with s.subsLock
-// taken by the caller.
+// it's not yet subscribed for them. It's supposed to be called with
+// s.subsCounterLock taken by the caller.
 func (s *Server) subscribeToChannel(event response.EventID) {
 	switch event {
 	case response.BlockEventID:
@@ -1080,6 +1940,11 @@ func (s *Server) subscribeToChannel(event response.EventID) {
 			s.chain.SubscribeForExecutions(s.executionCh)
 		}
 		s.executionSubs++
+	case response.MempoolTransactionAddedEventID, response.MempoolTransactionRemovedEventID:
+		if s.mempoolSubs == 0 {
+			s.chain.GetMemPool().SubscribeForTransactions(s.mempoolCh)
+		}
+		s.mempoolSubs++
 	}
 }
 
@@ -1090,20 +1955,29 @@ func (s *Server) unsubscribe(reqParams request.Params, sub *subscriber) (interfa
 		return nil, response.ErrInvalidParams
 	}
 	s.subsLock.Lock()
-	defer s.subsLock.Unlock()
 	if len(sub.feeds) <= id || sub.feeds[id].event == response.InvalidEventID {
+		s.subsLock.Unlock()
 		return nil, response.ErrInvalidParams
 	}
 	event := sub.feeds[id].event
+	replayed := s.stopReplay(&sub.feeds[id])
 	sub.feeds[id].event = response.InvalidEventID
-	sub.feeds[id].filter = nil
+	sub.feeds[id].filters = nil
+	s.subsLock.Unlock()
+	addSubscriptionsGauge(event, -1)
+
+	s.subsCounterLock.Lock()
 	s.unsubscribeFromChannel(event)
+	if replayed {
+		s.unsubscribeFromChannel(response.BlockEventID)
+	}
+	s.subsCounterLock.Unlock()
 	return true, nil
 }
 
 // unsubscribeFromChannel unsubscribes RPC server from appropriate chain events
 // if there are no other subscribers for it. It's supposed to be called with
-// s.subsLock taken by the caller.
+// s.subsCounterLock taken by the caller.
 func (s *Server) unsubscribeFromChannel(event response.EventID) {
 	switch event {
 	case response.BlockEventID:
@@ -1126,20 +2000,38 @@ func (s *Server) unsubscribeFromChannel(event response.EventID) {
 		if s.executionSubs == 0 {
 			s.chain.UnsubscribeFromExecutions(s.executionCh)
 		}
+	case response.MempoolTransactionAddedEventID, response.MempoolTransactionRemovedEventID:
+		s.mempoolSubs--
+		if s.mempoolSubs == 0 {
+			s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
+		}
 	}
 }
 
-func (s *Server) handleSubEvents() {
-	b, err := json.Marshal(response.Notification{
-		JSONRPC: request.JSONRPCVersion,
-		Event:   response.MissedEventID,
-		Payload: make([]interface{}, 0),
-	})
-	if err != nil {
-		s.log.Error("fatal: failed to marshal overflow event", zap.Error(err))
-		return
+// markMempoolOverflown handles mempool events dropped by the pool itself, all
+// subscribers of mempool events are treated as overflown then and receive
+// overflowMsg eventually. It must be called with subsLock held.
+func (s *Server) markMempoolOverflown(overflowMsg *websocket.PreparedMessage) {
+	for sub := range s.subscribers {
+		for i := range sub.feeds {
+			if sub.feeds[i].event != response.MempoolTransactionAddedEventID &&
+				sub.feeds[i].event != response.MempoolTransactionRemovedEventID {
+				continue
+			}
+			if !sub.overflown.Load() {
+				sub.overflown.Store(true)
+				go func(sub *subscriber) {
+					sub.writer <- overflowMsg
+					sub.overflown.Store(false)
+				}(sub)
+			}
+			break
+		}
 	}
-	overflowMsg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
+
+func (s *Server) handleSubEvents() {
+	overflowMsg, err := newMissedEventMessage()
 	if err != nil {
 		s.log.Error("fatal: failed to prepare overflow message", zap.Error(err))
 		return
@@ -1150,13 +2042,17 @@ chloop:
 			JSONRPC: request.JSONRPCVersion,
 			Payload: make([]interface{}, 1),
 		}
-		var msg *websocket.PreparedMessage
+		var (
+			msg        *websocket.PreparedMessage
+			blockIndex uint32
+		)
 		select {
 		case <-s.shutdown:
 			break chloop
 		case b := <-s.blockCh:
 			resp.Event = response.BlockEventID
 			resp.Payload[0] = b
+			blockIndex = b.Index
 		case execution := <-s.executionCh:
 			resp.Event = response.ExecutionEventID
 			resp.Payload[0] = result.NewApplicationLog(execution)
@@ -1166,17 +2062,30 @@ chloop:
 		case tx := <-s.transactionCh:
 			resp.Event = response.TransactionEventID
 			resp.Payload[0] = tx
+		case e := <-s.mempoolCh:
+			if e.Type == mempool.EventsMissed {
+				s.subsLock.RLock()
+				s.markMempoolOverflown(overflowMsg)
+				s.subsLock.RUnlock()
+				continue
+			}
+			resp.Event = response.MempoolTransactionAddedEventID
+			if e.Type == mempool.TransactionRemoved {
+				resp.Event = response.MempoolTransactionRemovedEventID
+			}
+			resp.Payload[0] = result.MempoolEvent{Transaction: e.Tx, Reason: e.Reason}
 		}
 		s.subsLock.RLock()
 	subloop:
 		for sub := range s.subscribers {
-			if sub.overflown.Load() {
-				continue
-			}
 			for i := range sub.feeds {
 				if sub.feeds[i].Matches(&resp) {
+					if sub.overflown.Load() {
+						incDroppedCounter(resp.Event)
+						break
+					}
 					if msg == nil {
-						b, err = json.Marshal(resp)
+						b, err := json.Marshal(resp)
 						if err != nil {
 							s.log.Error("failed to marshal notification",
 								zap.Error(err),
@@ -1191,9 +2100,15 @@ chloop:
 							break subloop
 						}
 					}
+					// Live events are collected during replay.
+					if r := sub.feeds[i].replay; r != nil {
+						r.add(msg, blockIndex)
+						continue
+					}
 					select {
 					case sub.writer <- msg:
 					default:
+						incDroppedCounter(resp.Event)
 						sub.overflown.Store(true)
 						// MissedEvent is to be delivered eventually.
 						go func(sub *subscriber) {
@@ -1206,19 +2121,40 @@ chloop:
 				}
 			}
 		}
+		if resp.Event == response.BlockEventID {
+			// All events of this block are processed at this point.
+			for sub := range s.subscribers {
+				for i := range sub.feeds {
+					if r := sub.feeds[i].replay; r != nil {
+						r.setMarker(blockIndex)
+					}
+				}
+			}
+		}
 		s.subsLock.RUnlock()
 	}
-	// It's important to do it with lock held because no subscription routine
-	// should be running concurrently to this one. And even if one is to run
-	// after unlock, it'll see closed s.shutdown and won't subscribe.
-	s.subsLock.Lock()
-	// There might be no subscription in reality, but it's not a problem as
-	// core.Blockchain allows unsubscribing non-subscribed channels.
-	s.chain.UnsubscribeFromBlocks(s.blockCh)
-	s.chain.UnsubscribeFromTransactions(s.transactionCh)
-	s.chain.UnsubscribeFromNotifications(s.notificationCh)
-	s.chain.UnsubscribeFromExecutions(s.executionCh)
-	s.subsLock.Unlock()
+	// Event dispatchers might be blocked sending events to us, so channels
+	// are drained while unsubscribing.
+	unsubDone := make(chan struct{})
+	go func() {
+		// It's important to do it with lock held because no subscription
+		// routine should be running concurrently to this one. And even if
+		// one is to run after unlock, it'll see closed s.shutdown and won't
+		// subscribe.
+		s.subsCounterLock.Lock()
+		// There might be no subscription in reality, but it's not a problem
+		// as core.Blockchain and mempool.Pool allow unsubscribing
+		// non-subscribed channels.
+		s.chain.UnsubscribeFromBlocks(s.blockCh)
+		s.chain.UnsubscribeFromTransactions(s.transactionCh)
+		s.chain.UnsubscribeFromNotifications(s.notificationCh)
+		s.chain.UnsubscribeFromExecutions(s.executionCh)
+		if s.mempoolSubs != 0 {
+			s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
+		}
+		s.subsCounterLock.Unlock()
+		close(unsubDone)
+	}()
 drainloop:
 	for {
 		select {
@@ -1226,7 +2162,8 @@ drainloop:
 		case <-s.executionCh:
 		case <-s.notificationCh:
 		case <-s.transactionCh:
-		default:
+		case <-s.mempoolCh:
+		case <-unsubDone:
 			break drainloop
 		}
 	}
@@ -1236,6 +2173,7 @@ drainloop:
 	close(s.transactionCh)
 	close(s.notificationCh)
 	close(s.executionCh)
+	close(s.mempoolCh)
 }
 
 func (s *Server) blockHeightFromParam(param *request.Param) (int, *response.Error) {
@@ -1272,6 +2210,9 @@ func (s *Server) packResponseToRaw(r *request.In, result interface{}, respErr *r
 			resp.Result = resJSON
 		}
 	}
+	if resp.Error != nil {
+		incErrorCounter(r.Method, resp.Error)
+	}
 	return resp
 }
 
@@ -1302,11 +2243,7 @@ func (s *Server) writeHTTPServerResponse(r *request.In, w http.ResponseWriter, r
 		s.logRequestError(r, resp.Error)
 		w.WriteHeader(resp.Error.HTTPCode)
 	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	if s.config.EnableCORSWorkaround {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
-	}
+	s.setHTTPHeaders(w)
 
 	encoder := json.NewEncoder(w)
 	err := encoder.Encode(resp)
@@ -1318,6 +2255,30 @@ func (s *Server) writeHTTPServerResponse(r *request.In, w http.ResponseWriter, r
 	}
 }
 
+// writeHTTPBatchResponse writes batch response to the ResponseWriter, errors
+// of particular requests are already logged by handleBatch and batch
+// responses are always returned with 200 HTTP code.
+func (s *Server) writeHTTPBatchResponse(w http.ResponseWriter, resps response.Batch) {
+	s.setHTTPHeaders(w)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(resps)
+
+	if err != nil {
+		s.log.Error("Error encountered while encoding batch response",
+			zap.String("err", err.Error()),
+			zap.Int("requests", len(resps)))
+	}
+}
+
+func (s *Server) setHTTPHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if s.config.EnableCORSWorkaround {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+	}
+}
+
 // validateAddress verifies that the address is a correct NEO address
 // see https://docs.neo.org/en-us/node/cli/2.9.4/api/validateaddress.html
 func validateAddress(addr interface{}) result.ValidateAddress {
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -61,7 +62,16 @@ func getTestBlocks(t *testing.T) []*block.Block {
 }
 
 func initClearServerWithInMemoryChain(t *testing.T) (*core.Blockchain, *Server, *httptest.Server) {
+	return initClearServerWithCustomConfig(t, nil)
+}
+
+// initClearServerWithCustomConfig allows to change RPC server configuration
+// loaded from the unit test config before server creation.
+func initClearServerWithCustomConfig(t *testing.T, f func(*rpc.Config)) (*core.Blockchain, *Server, *httptest.Server) {
 	chain, cfg, logger := getUnitTestChain(t)
+	if f != nil {
+		f(&cfg.ApplicationConfiguration.RPC)
+	}
 
 	serverConfig := network.NewServerConfig(cfg)
 	server, err := network.NewServer(serverConfig, chain, logger)
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -20,13 +22,17 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
@@ -55,6 +61,64 @@ const testContractHash = "93c4983afe01a75f74c1e56011bd630e9d8cc755"
 const deploymentTxHash = "583cf0e49d69d8854869efc3e97ad741061da478292a7280580789351a39a1ac"
 
 var rpcTestCases = map[string][]rpcTestCase{
+	"calculatenetworkfee": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "not a hex",
+			params: `["qwerty"]`,
+			fail:   true,
+		},
+		{
+			name:   "not a transaction",
+			params: `["0102"]`,
+			fail:   true,
+		},
+	},
+	"findstates": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown contract",
+			params: `["0000000000000000000000000000000000000000", ""]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid prefix",
+			params: `["` + testContractHash + `", "notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "start doesn't match prefix",
+			params: `["` + testContractHash + `", "74", "00"]`,
+			fail:   true,
+		},
+		{
+			name:   "negative limit",
+			params: `["` + testContractHash + `", "", "", -1]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid root",
+			params: `["` + testContractHash + `", "", "", 0, "notahash"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive",
+			params: `["` + testContractHash + `", "746573746b6579"]`,
+			result: func(e *executor) interface{} {
+				return &result.FindStates{
+					Results: []result.KeyValue{{Key: []byte("testkey"), Value: []byte("testvalue")}},
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name:   "positive",
@@ -86,6 +150,85 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getblocknotifications": {
+		{
+			name:   "positive",
+			params: `[6]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				require.Equal(t, expectedBlockNotifications(t, e, 6, nil), res)
+			},
+		},
+		{
+			name:   "positive, filtered by contract",
+			params: `[6, {"contract": "` + testContractHash + `"}]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				expected := expectedBlockNotifications(t, e, 6, func(ne result.NotificationEvent) bool {
+					return ne.Contract.StringLE() == testContractHash
+				})
+				require.Equal(t, expected, res)
+				require.Equal(t, 1, len(res.Notifications))
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(6))
+				require.NoError(t, err)
+				require.Equal(t, b.Transactions[0].Hash(), res.Notifications[0].Container)
+				require.Equal(t, "transfer", res.Notifications[0].Name)
+			},
+		},
+		{
+			name:   "positive, filter alternatives",
+			params: `[6, [{"contract": "` + testContractHash + `"}, {"name": "Transfer"}]]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				expected := expectedBlockNotifications(t, e, 6, func(ne result.NotificationEvent) bool {
+					return ne.Contract.StringLE() == testContractHash || ne.Name == "Transfer"
+				})
+				require.Equal(t, expected, res)
+			},
+		},
+		{
+			name:   "positive, nothing matches",
+			params: `[6, {"name": "nonexistent"}]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				require.Equal(t, uint32(6), res.Index)
+				require.Equal(t, 0, len(res.Notifications))
+			},
+		},
+		{
+			name:   "positive, genesis",
+			params: `[0]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				require.Equal(t, expectedBlockNotifications(t, e, 0, nil), res)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "bad index",
+			params: `[100500]`,
+			fail:   true,
+		},
+		{
+			name:   "bad filter",
+			params: `[6, {"state": "HALT"}]`,
+			fail:   true,
+		},
+	},
 	"getcontractstate": {
 		{
 			name:   "positive",
@@ -114,6 +257,30 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 	},
 
+	"getnativecontracts": {
+		{
+			params: "[]",
+			result: func(e *executor) interface{} {
+				return new([]state.NativeContract)
+			},
+			check: func(t *testing.T, e *executor, result interface{}) {
+				res, ok := result.(*[]state.NativeContract)
+				require.True(t, ok)
+				require.Equal(t, e.chain.GetNatives(), *res)
+				names := make([]string, 0, len(*res))
+				for _, nc := range *res {
+					names = append(names, nc.Name)
+					require.NotEqual(t, 0, len(nc.Methods))
+					for _, m := range nc.Methods {
+						require.True(t, m.Price >= 0)
+					}
+				}
+				require.Contains(t, names, "NEO")
+				require.Contains(t, names, "GAS")
+				require.Contains(t, names, "Policy")
+			},
+		},
+	},
 	"getnep5balances": {
 		{
 			name:   "no params",
@@ -166,6 +333,78 @@ var rpcTestCases = map[string][]rpcTestCase{
 			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
 			check:  checkNep5Transfers,
 		},
+		{
+			name:   "invalid limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "limit too big",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 1001]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 3, -1]`,
+			fail:   true,
+		},
+		{
+			name:   "page too big",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 1000, 2147484]`,
+			fail:   true,
+		},
+		{
+			name:   "positive, all in one page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 1000]`,
+			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
+			check:  checkNep5Transfers,
+		},
+		{
+			name:   "positive, first page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 3]`,
+			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				checkNep5TransfersPage(t, e, acc, 3, 0)
+			},
+		},
+		{
+			name:   "positive, second page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 3, 1]`,
+			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				checkNep5TransfersPage(t, e, acc, 3, 1)
+			},
+		},
+		{
+			name:   "positive, page after the last one",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 9999999999999, 3, 100]`,
+			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				checkNep5TransfersPage(t, e, acc, 3, 100)
+			},
+		},
+	},
+	"getstateroot": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["0x1234567890"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown block",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown height",
+			params: `[100500]`,
+			fail:   true,
+		},
 	},
 	"getstorage": {
 		{
@@ -205,6 +444,50 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getproof": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid root",
+			params: `["0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid contract",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid key",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "` + testContractHash + `", "notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown contract",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "6d1eeca891ee93de2b7a77eb91c26f3b3c04d6c3", "746573746b6579"]`,
+			fail:   true,
+		},
+	},
+	"verifyproof": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid root",
+			params: `["0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid proof",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "0xabcdef"]`,
+			fail:   true,
+		},
+	},
 	"getbestblockhash": {
 		{
 			params: "[]",
@@ -444,6 +727,60 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getcommittee": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &keys.PublicKeys{}
+			},
+			check: func(t *testing.T, e *executor, committee interface{}) {
+				expected, err := e.chain.GetCommittee()
+				require.NoError(t, err)
+				actual, ok := committee.(*keys.PublicKeys)
+				require.True(t, ok)
+				require.Equal(t, expected, *actual)
+				require.True(t, sort.IsSorted(*actual))
+			},
+		},
+	},
+	"getnextblockvalidators": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &[]result.Validator{}
+			},
+			check: func(t *testing.T, e *executor, validators interface{}) {
+				pubs, err := e.chain.GetNextBlockValidators()
+				require.NoError(t, err)
+				expected := make([]result.Validator, 0, len(pubs))
+				for _, pub := range pubs {
+					expected = append(expected, result.Validator{
+						PublicKey: *pub,
+						Votes:     0,
+						Active:    true,
+					})
+				}
+				actual, ok := validators.(*[]result.Validator)
+				require.True(t, ok)
+				require.Equal(t, expected, *actual)
+			},
+		},
+	},
+	"getcandidates": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &[]result.Validator{}
+			},
+			check: func(t *testing.T, e *executor, candidates interface{}) {
+				enrollments, err := e.chain.GetEnrollments()
+				require.NoError(t, err)
+				actual, ok := candidates.(*[]result.Validator)
+				require.True(t, ok)
+				require.Equal(t, len(enrollments), len(*actual))
+			},
+		},
+	},
 	"getvalidators": {
 		{
 			params: "[]",
@@ -481,6 +818,38 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"invokecontractverify": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "not a scripthash",
+			params: `["qwerty"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown contract",
+			params: `["0000000000000000000000000000000000000000", []]`,
+			fail:   true,
+		},
+		{
+			name:   "no verify method",
+			params: `["` + testContractHash + `", []]`,
+			fail:   true,
+		},
+		{
+			name:   "bad args",
+			params: `["` + testContractHash + `", [{"type": "Integer", "value": "qwerty"}]]`,
+			fail:   true,
+		},
+		{
+			name:   "bad signers",
+			params: `["` + testContractHash + `", [], [42]]`,
+			fail:   true,
+		},
+	},
 	"invokefunction": {
 		{
 			name:   "positive",
@@ -514,6 +883,78 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [{"type": "Integer", "value": "qwerty"}]]`,
 			fail:   true,
 		},
+		{
+			name:   "positive, historic",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], 1]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.NotEqual(t, "", res.Script)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
+		{
+			name:   "positive, traced",
+			params: `["` + testContractHash + `", "putValue", [{"type": "ByteArray", "value": "AQI="}, {"type": "ByteArray", "value": "Aw=="}], [], null, 1]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State)
+				require.NotNil(t, res.Trace)
+				require.NotNil(t, res.Trace.Calls)
+				require.Equal(t, res.GasConsumed, res.Trace.Calls.GasConsumed)
+				require.Equal(t, 1, len(res.Trace.Calls.Calls))
+				call := res.Trace.Calls.Calls[0]
+				require.Equal(t, testContractHash, call.Contract.StringLE())
+				require.Equal(t, "putValue", call.Method)
+				require.True(t, call.GasConsumed > 0)
+				require.Equal(t, 1, len(res.Trace.Storage))
+				require.Equal(t, testContractHash, res.Trace.Storage[0].Contract.StringLE())
+				require.Equal(t, []byte{1, 2}, res.Trace.Storage[0].Key)
+				require.Equal(t, []byte{3}, res.Trace.Storage[0].NewValue)
+				require.False(t, res.Trace.Storage[0].Deleted)
+				require.Nil(t, res.Trace.Exception)
+			},
+		},
+		{
+			name:   "positive, historic, traced",
+			params: `["` + testContractHash + `", "putValue", [{"type": "ByteArray", "value": "AQI="}, {"type": "ByteArray", "value": "Aw=="}], [], 1, 1]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.NotEqual(t, "", res.State)
+				require.NotNil(t, res.Trace)
+			},
+		},
+		{
+			name:   "positive, not traced",
+			params: `["` + testContractHash + `", "putValue", [{"type": "ByteArray", "value": "AQI="}, {"type": "ByteArray", "value": "Aw=="}], [], null, 0]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State)
+				require.Nil(t, res.Trace)
+			},
+		},
+		{
+			name:   "bad height",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], 100500]`,
+			fail:   true,
+		},
+		{
+			name:   "bad state root",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], "qwerty"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown state root",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], "` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:   true,
+		},
 	},
 	"invokescript": {
 		{
@@ -587,6 +1028,35 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `[42]`,
 			fail:   true,
 		},
+		{
+			name:   "bad height",
+			params: `["51", [], 100500]`,
+			fail:   true,
+		},
+		{
+			name:   "bad state root",
+			params: `["51", [], "qwerty"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive, traced fault",
+			params: `["3711", [], null, 1]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "FAULT", res.State)
+				require.NotNil(t, res.Trace)
+				require.NotNil(t, res.Trace.Calls)
+				require.Equal(t, hash.Hash160([]byte{byte(opcode.ABORT), byte(opcode.PUSH1)}), res.Trace.Calls.Contract)
+				require.Equal(t, 0, len(res.Trace.Calls.Calls))
+				require.NotNil(t, res.Trace.Exception)
+				require.Equal(t, res.Trace.Calls.Contract, res.Trace.Exception.Contract)
+				require.Equal(t, 0, res.Trace.Exception.IP)
+				require.Equal(t, opcode.ABORT, res.Trace.Exception.Opcode)
+				require.NotEqual(t, "", res.Trace.Exception.Message)
+			},
+		},
 		{
 			name:   "bas string",
 			params: `["qwerty"]`,
@@ -725,6 +1195,175 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.Equal(t, "HALT", res.VMState)
 	})
 
+	t.Run("getproof", func(t *testing.T) {
+		r, err := chain.GetStateRoot(chain.BlockHeight())
+		require.NoError(t, err)
+
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getproof", "params": ["%s", "%s", "%x"]}`,
+			r.Root.StringLE(), testContractHash, []byte("testkey"))
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		res := new(result.GetProof)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.True(t, res.Success)
+		h, _ := util.Uint160DecodeStringLE(testContractHash)
+		cs := chain.GetContractState(h)
+		require.NotNil(t, cs)
+		skey := makeStorageKey(cs.ID, []byte("testkey"))
+		require.Equal(t, skey, res.Result.Key)
+		require.True(t, len(res.Result.Proof) > 0)
+
+		rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+			r.Root.StringLE(), res.Result.String())
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		rawRes = checkErrGetResult(t, body, false)
+		vp := new(result.VerifyProof)
+		require.NoError(t, json.Unmarshal(rawRes, vp))
+		require.Equal(t, []byte("testvalue"), vp.Result)
+
+		t.Run("missing key", func(t *testing.T) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getproof", "params": ["%s", "%s", "%x"]}`,
+				r.Root.StringLE(), testContractHash, []byte("badkey"))
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			res := new(result.GetProof)
+			require.NoError(t, json.Unmarshal(rawRes, res))
+			require.False(t, res.Success)
+		})
+		t.Run("invalid proof", func(t *testing.T) {
+			p := res.Result
+			p.Key = makeStorageKey(cs.ID, []byte("badkey"))
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+				r.Root.StringLE(), p.String())
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			vp := new(result.VerifyProof)
+			require.NoError(t, json.Unmarshal(rawRes, vp))
+			require.Nil(t, vp.Result)
+		})
+	})
+
+	t.Run("findstates", func(t *testing.T) {
+		r, err := chain.GetStateRoot(chain.BlockHeight())
+		require.NoError(t, err)
+		find := func(t *testing.T, params string) *result.FindStates {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": [%s]}`, params)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			res := new(result.FindStates)
+			require.NoError(t, json.Unmarshal(rawRes, res))
+			return res
+		}
+
+		all := find(t, `"`+testContractHash+`", ""`)
+		require.False(t, all.Truncated)
+		require.True(t, len(all.Results) > 1)
+		require.Nil(t, all.FirstProof)
+		for i := 1; i < len(all.Results); i++ {
+			require.True(t, bytes.Compare(all.Results[i-1].Key, all.Results[i].Key) < 0)
+		}
+
+		t.Run("paging", func(t *testing.T) {
+			var start string
+			var items []result.KeyValue
+			for {
+				res := find(t, fmt.Sprintf(`"%s", "", "%s", 1`, testContractHash, start))
+				items = append(items, res.Results...)
+				if !res.Truncated {
+					break
+				}
+				require.Equal(t, 1, len(res.Results))
+				start = hex.EncodeToString(res.Results[0].Key)
+			}
+			require.Equal(t, all.Results, items)
+		})
+		t.Run("state root", func(t *testing.T) {
+			res := find(t, fmt.Sprintf(`"%s", "", "", 0, "%s"`, testContractHash, r.Root.StringLE()))
+			require.Equal(t, all.Results, res.Results)
+			require.False(t, res.Truncated)
+
+			res = find(t, fmt.Sprintf(`"%s", "", "%x", 2, "%s"`, testContractHash,
+				all.Results[0].Key, r.Root.StringLE()))
+			require.Equal(t, all.Results[1:3], res.Results)
+			require.Equal(t, len(all.Results) > 3, res.Truncated)
+			for i, p := range []*result.ProofWithKey{res.FirstProof, res.LastProof} {
+				require.NotNil(t, p)
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+					r.Root.StringLE(), p.String())
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				rawRes := checkErrGetResult(t, body, false)
+				vp := new(result.VerifyProof)
+				require.NoError(t, json.Unmarshal(rawRes, vp))
+				require.Equal(t, res.Results[i].Value, vp.Result)
+			}
+		})
+	})
+
+	t.Run("getstateroot", func(t *testing.T) {
+		testRoot := func(t *testing.T, p string) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getstateroot", "params": [%s]}`, p)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+
+			res := new(state.MPTRootState)
+			require.NoError(t, json.Unmarshal(rawRes, res))
+			require.NotEqual(t, util.Uint256{}, res.Root)
+			require.Equal(t, state.Unverified, res.Flag)
+		}
+		t.Run("ByHeight", func(t *testing.T) { testRoot(t, "5") })
+		t.Run("ByHash", func(t *testing.T) { testRoot(t, `"`+chain.GetHeaderHash(5).StringLE()+`"`) })
+	})
+
+	t.Run("getstateheight", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getstateheight", "params": []}`
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+
+		res := new(result.StateHeight)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.Equal(t, chain.BlockHeight(), res.BlockHeight)
+		require.Equal(t, chain.StateHeight(), res.StateHeight)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		rpc := `[{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []},
+			{"jsonrpc": "2.0", "id": 2, "method": "getstateheight", "params": []},
+			{"jsonrpc": "1.0", "id": 3, "method": "getblockcount", "params": []},
+			{"jsonrpc": "2.0", "id": 4, "method": "unknownmethod", "params": []}]`
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		var resps response.Batch
+		require.NoError(t, json.Unmarshal(body, &resps))
+		require.Equal(t, 4, len(resps))
+		for i := range resps {
+			require.Equal(t, strconv.Itoa(i+1), string(resps[i].ID))
+			require.Equal(t, defaultJSONRPC, resps[i].JSONRPC)
+		}
+		require.Nil(t, resps[0].Error)
+		var count uint32
+		require.NoError(t, json.Unmarshal(resps[0].Result, &count))
+		require.Equal(t, chain.BlockHeight()+1, count)
+		require.Nil(t, resps[1].Error)
+		require.NotNil(t, resps[2].Error)
+		require.Equal(t, response.NewInvalidRequestError("", nil).Code, resps[2].Error.Code)
+		require.NotNil(t, resps[3].Error)
+		require.Equal(t, response.NewMethodNotFoundError("", nil).Code, resps[3].Error.Code)
+
+		t.Run("empty", func(t *testing.T) {
+			body := doRPCCall(`[]`, httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+		// Such batch doesn't fit into websocket read limit, so it's
+		// only checked via HTTP.
+		t.Run("too big", func(t *testing.T) {
+			reqs := make([]string, defaultMaxBatchSize+1)
+			for i := range reqs {
+				reqs[i] = fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "getblockcount", "params": []}`, i)
+			}
+			body := doRPCCallOverHTTP("["+strings.Join(reqs, ",")+"]", httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+	})
+
 	t.Run("submit", func(t *testing.T) {
 		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitblock", "params": ["%s"]}`
 		t.Run("invalid signature", func(t *testing.T) {
@@ -773,6 +1412,82 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		})
 	})
 
+	t.Run("testsendrawtransaction", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "testsendrawtransaction", "params": ["%s"]}`
+		priv0 := testchain.PrivateKeyByID(0)
+		acc0, err := wallet.NewAccountFromWIF(priv0.WIF())
+		require.NoError(t, err)
+
+		newTx := func(acc *wallet.Account) *transaction.Transaction {
+			height := chain.BlockHeight()
+			tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+			tx.Nonce = height + 1
+			tx.ValidUntilBlock = height + 10
+			tx.Signers = []transaction.Signer{{Account: acc.PrivateKey().GetScriptHash()}}
+			size := io.GetVarSize(tx)
+			netFee, sizeDelta := core.CalculateNetworkFee(acc.Contract.Script)
+			tx.NetworkFee += netFee
+			size += sizeDelta
+			tx.NetworkFee += int64(size) * chain.FeePerByte()
+			return tx
+		}
+		sign := func(acc *wallet.Account, tx *transaction.Transaction) *transaction.Transaction {
+			require.NoError(t, acc.SignTx(tx))
+			return tx
+		}
+		check := func(t *testing.T, tx *transaction.Transaction, reason result.TxFailureReason, signer *int) {
+			poolSize := chain.GetMemPool().Count()
+			body := doRPCCall(fmt.Sprintf(rpc, hex.EncodeToString(tx.Bytes())), httpSrv.URL, t)
+			data := checkErrGetResult(t, body, false)
+			res := new(result.TxVerification)
+			require.NoError(t, json.Unmarshal(data, res))
+			require.Equal(t, tx.Hash(), res.Hash)
+			require.Equal(t, reason == "", res.Valid)
+			require.Equal(t, reason, res.Reason)
+			require.Equal(t, signer, res.Signer)
+			require.Equal(t, reason == "", res.Message == "")
+			require.Equal(t, poolSize, chain.GetMemPool().Count())
+		}
+
+		t.Run("invalid params", func(t *testing.T) {
+			body := doRPCCall(fmt.Sprintf(rpc, "notahex"), httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+			body = doRPCCall(fmt.Sprintf(rpc, "0274d792072617720636f6e747261637"), httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+		t.Run("positive", func(t *testing.T) {
+			tx := sign(acc0, newTx(acc0))
+			check(t, tx, "", nil)
+			require.False(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+		})
+		t.Run("expired", func(t *testing.T) {
+			tx := newTx(acc0)
+			tx.ValidUntilBlock = chain.BlockHeight()
+			check(t, sign(acc0, tx), result.TxExpired, nil)
+		})
+		t.Run("invalid witness", func(t *testing.T) {
+			tx := sign(acc0, newTx(acc0))
+			tx.Scripts[0].InvocationScript[10] ^= 0xff
+			signer := 0
+			check(t, tx, result.TxWitness, &signer)
+		})
+		t.Run("missing witness", func(t *testing.T) {
+			check(t, newTx(acc0), result.TxWitness, nil)
+		})
+		t.Run("insufficient funds", func(t *testing.T) {
+			acc, err := wallet.NewAccount()
+			require.NoError(t, err)
+			check(t, sign(acc, newTx(acc)), result.TxInsufficientFunds, nil)
+		})
+		t.Run("already exists", func(t *testing.T) {
+			tx := newTx(acc0)
+			tx.Nonce++
+			sign(acc0, tx)
+			require.NoError(t, chain.PoolTx(tx))
+			check(t, tx, result.TxAlreadyExists, nil)
+		})
+	})
+
 	t.Run("getrawtransaction", func(t *testing.T) {
 		block, _ := chain.GetBlock(chain.GetHeaderHash(0))
 		tx := block.Transactions[0]
@@ -893,6 +1608,23 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoErrorf(t, err, "could not parse response: %s", res)
 
 		assert.ElementsMatch(t, expected, actual)
+
+		rpc = `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [1, 1]}`
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		res = checkErrGetResult(t, body, false)
+
+		details := new(result.RawMempoolDetails)
+		require.NoErrorf(t, json.Unmarshal(res, details), "could not parse response: %s", res)
+		require.Equal(t, chain.BlockHeight(), details.Height)
+		actual = actual[:0]
+		for _, tx := range details.Transactions {
+			actual = append(actual, tx.Hash)
+			require.True(t, tx.Verified)
+			pooled, ok := mp.TryGetValue(tx.Hash)
+			require.True(t, ok)
+			require.Equal(t, result.NewMempoolTransaction(pooled, true), tx)
+		}
+		assert.ElementsMatch(t, expected, actual)
 	})
 
 	t.Run("getnep5transfers", func(t *testing.T) {
@@ -963,6 +1695,7 @@ func doRPCCallOverWS(rpcCall string, url string, t *testing.T) []byte {
 	url = "ws" + strings.TrimPrefix(url, "http")
 	c, _, err := dialer.Dial(url+"/ws", nil)
 	require.NoError(t, err)
+	defer c.Close()
 	c.SetWriteDeadline(time.Now().Add(time.Second))
 	require.NoError(t, c.WriteMessage(1, []byte(rpcCall)))
 	c.SetReadDeadline(time.Now().Add(time.Second))
@@ -980,6 +1713,42 @@ func doRPCCallOverHTTP(rpcCall string, url string, t *testing.T) []byte {
 	return bytes.TrimSpace(body)
 }
 
+// expectedBlockNotifications collects notifications of the given block
+// directly from the chain, filter is optional.
+func expectedBlockNotifications(t *testing.T, e *executor, index uint32, filter func(result.NotificationEvent) bool) *result.BlockNotifications {
+	b, err := e.chain.GetBlock(e.chain.GetHeaderHash(int(index)))
+	require.NoError(t, err)
+	var hashes []util.Uint256
+	if index > 0 {
+		hashes = append(hashes, b.Hash())
+	}
+	for _, tx := range b.Transactions {
+		hashes = append(hashes, tx.Hash())
+	}
+	res := &result.BlockNotifications{
+		Hash:          b.Hash(),
+		Index:         b.Index,
+		Notifications: []result.ContainedNotificationEvent{},
+	}
+	for _, h := range hashes {
+		aer, err := e.chain.GetAppExecResult(h)
+		require.NoError(t, err)
+		if aer.Trigger != trigger.System && aer.VMState != vm.HaltState {
+			continue
+		}
+		for _, ev := range aer.Events {
+			ne := result.StateEventToResultNotification(ev)
+			if filter == nil || filter(ne) {
+				res.Notifications = append(res.Notifications, result.ContainedNotificationEvent{
+					Container:         h,
+					NotificationEvent: ne,
+				})
+			}
+		}
+	}
+	return res
+}
+
 func checkNep5Balances(t *testing.T, e *executor, acc interface{}) {
 	res, ok := acc.(*result.NEP5Balances)
 	require.True(t, ok)
@@ -1012,6 +1781,39 @@ func checkNep5Transfers(t *testing.T, e *executor, acc interface{}) {
 	checkNep5TransfersAux(t, e, acc, 0, e.chain.HeaderHeight())
 }
 
+// checkNep5TransfersPage checks that the page of transfers contains
+// transfers from the expected blocks (going from the newest to the oldest).
+func checkNep5TransfersPage(t *testing.T, e *executor, acc interface{}, limit, page int) {
+	res, ok := acc.(*result.NEP5Transfers)
+	require.True(t, ok)
+
+	var all []uint32
+	u := testchain.PrivateKeyByID(0).GetScriptHash()
+	require.NoError(t, e.chain.SeekNEP5Transfers(u, 0, math.MaxUint64, func(tr *state.NEP5Transfer) (bool, error) {
+		all = append(all, tr.Block)
+		return true, nil
+	}))
+	for i := 1; i < len(all); i++ {
+		require.True(t, all[i-1] >= all[i])
+	}
+	var expected []uint32
+	if limit*page < len(all) {
+		expected = all[limit*page:]
+		if len(expected) > limit {
+			expected = expected[:limit]
+		}
+	}
+
+	var actual []uint32
+	for _, tr := range res.Sent {
+		actual = append(actual, tr.Index)
+	}
+	for _, tr := range res.Received {
+		actual = append(actual, tr.Index)
+	}
+	require.ElementsMatch(t, expected, actual)
+}
+
 func checkNep5TransfersAux(t *testing.T, e *executor, acc interface{}, start, end uint32) {
 	res, ok := acc.(*result.NEP5Transfers)
 	require.True(t, ok)
@@ -1110,7 +1912,7 @@ func checkNep5TransfersAux(t *testing.T, e *executor, acc interface{}, start, en
 					Timestamp: b.Timestamp,
 					Asset:     e.chain.UtilityTokenHash(),
 					Address:   "", // burn has empty receiver
-					Amount:    amountToString(big.NewInt(amount), 8),
+					Amount:    util.BigFixedNToString(big.NewInt(amount), 8),
 					Index:     b.Index,
 					TxHash:    b.Hash(),
 				})
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// session holds iterators returned by a single invocation, it's removed
+// after some time of inactivity or by explicit terminatesession call.
+type session struct {
+	iterators map[string]stackitem.Item
+	timer     *time.Timer
+}
+
+const (
+	// Default session lifetime (since the last access) in seconds.
+	defaultSessionExpirationTime = 60
+
+	// Default maximum number of concurrent sessions.
+	defaultSessionPoolSize = 20
+
+	// Default maximum number of items returned by traverseiterator.
+	defaultMaxIteratorResultItems = 100
+)
+
+// newSessionID returns random UUID-formatted identifier for sessions and
+// iterators.
+func newSessionID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	// Version 4, variant 1.
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// registerSession moves iterators found on the invocation result stack into
+// a new session (if sessions are enabled) replacing them with references
+// that can be traversed later with traverseiterator.
+func (s *Server) registerSession(res *result.Invoke) (*result.Invoke, *response.Error) {
+	if !s.config.SessionEnabled {
+		return res, nil
+	}
+	var iterators map[string]stackitem.Item
+	for i := range res.Stack {
+		if !vm.IsIterator(res.Stack[i]) {
+			continue
+		}
+		id, err := newSessionID()
+		if err != nil {
+			return nil, response.NewInternalServerError("can't create iterator ID", err)
+		}
+		if iterators == nil {
+			iterators = make(map[string]stackitem.Item)
+		}
+		iterators[id] = res.Stack[i]
+		res.Stack[i] = stackitem.NewInterop(result.Iterator{ID: id})
+	}
+	if iterators == nil {
+		return res, nil
+	}
+	id, err := newSessionID()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't create session ID", err)
+	}
+
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	if len(s.sessions) >= s.config.SessionPoolSize {
+		return nil, response.NewRPCError("Max session capacity reached", "", nil)
+	}
+	s.sessions[id] = &session{
+		iterators: iterators,
+		timer: time.AfterFunc(s.sessionExpiration(), func() {
+			s.sessionsLock.Lock()
+			delete(s.sessions, id)
+			s.sessionsLock.Unlock()
+		}),
+	}
+	res.Session = id
+	return res, nil
+}
+
+func (s *Server) sessionExpiration() time.Duration {
+	return time.Duration(s.config.SessionExpirationTime) * time.Second
+}
+
+// terminateSessions removes all sessions, it's used on shutdown.
+func (s *Server) terminateSessions() {
+	s.sessionsLock.Lock()
+	for id, sess := range s.sessions {
+		sess.timer.Stop()
+		delete(s.sessions, id)
+	}
+	s.sessionsLock.Unlock()
+}
+
+// traverseIterator implements the `traverseiterator` RPC call.
+func (s *Server) traverseIterator(reqParams request.Params) (interface{}, *response.Error) {
+	if !s.config.SessionEnabled {
+		return nil, response.NewRPCError("Sessions are disabled", "", nil)
+	}
+	sessionID, err := reqParams.ValueWithType(0, request.StringT).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	iteratorID, err := reqParams.ValueWithType(1, request.StringT).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	count, err := reqParams.ValueWithType(2, request.NumberT).GetInt()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if count <= 0 || count > s.config.MaxIteratorResultItems {
+		return nil, response.NewInvalidParamsError(
+			fmt.Sprintf("count should be positive and not exceed %d", s.config.MaxIteratorResultItems), nil)
+	}
+
+	// Iterators are not safe for concurrent use, so the lock is held
+	// during traversal.
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, response.NewRPCError("Unknown session", "", nil)
+	}
+	iter, ok := sess.iterators[iteratorID]
+	if !ok {
+		return nil, response.NewRPCError("Unknown iterator", "", nil)
+	}
+	sess.timer.Reset(s.sessionExpiration())
+
+	var res = make([]json.RawMessage, 0)
+	for len(res) < count {
+		item, ok := vm.IterateNext(iter)
+		if !ok {
+			break
+		}
+		data, err := stackitem.ToJSONWithTypes(item)
+		if err != nil {
+			return nil, response.NewInternalServerError("can't encode iterator value", err)
+		}
+		res = append(res, data)
+	}
+	return res, nil
+}
+
+// terminateSession implements the `terminatesession` RPC call.
+func (s *Server) terminateSession(reqParams request.Params) (interface{}, *response.Error) {
+	if !s.config.SessionEnabled {
+		return nil, response.NewRPCError("Sessions are disabled", "", nil)
+	}
+	sessionID, err := reqParams.ValueWithType(0, request.StringT).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if ok {
+		sess.timer.Stop()
+		delete(s.sessions, sessionID)
+	}
+	return ok, nil
+}
@@ -1,6 +1,10 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -16,15 +20,25 @@ type (
 		writer    chan<- *websocket.PreparedMessage
 		ws        *websocket.Conn
 		overflown atomic.Bool
+		// authenticated is set for clients that have provided valid
+		// credentials when establishing connection.
+		authenticated bool
 		// These work like slots as there is not a lot of them (it's
 		// cheaper doing it this way rather than creating a map),
 		// pointing to EventID is an obvious overkill at the moment, but
 		// that's not for long.
 		feeds [maxFeeds]feed
+		// replayQueue contains replays that are to be started after
+		// subscription response is sent.
+		replayQueue []*replay
 	}
 	feed struct {
-		event  response.EventID
-		filter interface{}
+		event response.EventID
+		// filters is a list of alternatives, event matches the feed if
+		// it matches any of them (or if there are no filters).
+		filters []interface{}
+		// replay is not nil while historic events are being replayed.
+		replay *replay
 	}
 )
 
@@ -47,39 +61,149 @@ func (f *feed) Matches(r *response.Notification) bool {
 	if r.Event != f.event {
 		return false
 	}
-	if f.filter == nil {
+	payload := r.Payload[0]
+	if e, ok := payload.(result.MempoolEvent); ok {
+		// Mempool events are filtered by transaction.
+		payload = e.Transaction
+	}
+	return filtersMatch(f.filters, payload)
+}
+
+// filtersMatch checks payload against the list of filter alternatives, it
+// matches if any of them does, an empty list matches everything.
+func filtersMatch(filters []interface{}, payload interface{}) bool {
+	if len(filters) == 0 {
 		return true
 	}
-	switch f.event {
-	case response.BlockEventID:
-		filt := f.filter.(request.BlockFilter)
-		b := r.Payload[0].(*block.Block)
-		return int(b.ConsensusData.PrimaryIndex) == filt.Primary
-	case response.TransactionEventID:
-		filt := f.filter.(request.TxFilter)
-		tx := r.Payload[0].(*transaction.Transaction)
-		senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
-		signerOK := true
-		if filt.Signer != nil {
-			signerOK = false
-			for i := range tx.Signers {
-				if tx.Signers[i].Account.Equals(*filt.Signer) {
-					signerOK = true
-					break
+	for _, filter := range filters {
+		if filterMatches(filter, payload) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterMatches checks event payload against one of feed filters.
+func filterMatches(filter interface{}, payload interface{}) bool {
+	switch filt := filter.(type) {
+	case request.BlockFilter:
+		b := payload.(*block.Block)
+		if !filt.AnyPrimary && int(b.ConsensusData.PrimaryIndex) != filt.Primary {
+			return false
+		}
+		if filt.Transaction != nil {
+			for _, tx := range b.Transactions {
+				if filterMatches(*filt.Transaction, tx) {
+					return true
 				}
 			}
+			return false
+		}
+		return true
+	case request.TxFilter:
+		tx := payload.(*transaction.Transaction)
+		if filt.Sender != nil && !tx.Sender().Equals(*filt.Sender) {
+			return false
 		}
-		return senderOK && signerOK
-	case response.NotificationEventID:
-		filt := f.filter.(request.NotificationFilter)
-		notification := r.Payload[0].(result.NotificationEvent)
+		if filt.Signer == nil && filt.Scopes == nil {
+			return true
+		}
+		for i := range tx.Signers {
+			if (filt.Signer == nil || tx.Signers[i].Account.Equals(*filt.Signer)) &&
+				(filt.Scopes == nil || scopesMatch(tx.Signers[i].Scopes, *filt.Scopes)) {
+				return true
+			}
+		}
+		return false
+	case request.NotificationFilter:
+		notification := payload.(result.NotificationEvent)
 		hashOk := filt.Contract == nil || notification.Contract.Equals(*filt.Contract)
 		nameOk := filt.Name == nil || notification.Name == *filt.Name
 		return hashOk && nameOk
-	case response.ExecutionEventID:
-		filt := f.filter.(request.ExecutionFilter)
-		applog := r.Payload[0].(result.ApplicationLog)
-		return applog.VMState == filt.State
+	case request.ExecutionFilter:
+		applog := payload.(result.ApplicationLog)
+		if filt.State != "" && applog.VMState != filt.State {
+			return false
+		}
+		if filt.Contract != nil {
+			for i := range applog.Events {
+				if applog.Events[i].Contract.Equals(*filt.Contract) {
+					return true
+				}
+			}
+			return false
+		}
+		return true
 	}
 	return false
 }
+
+// scopesMatch checks whether signer scopes include all of the filter scopes,
+// FeeOnly filter only matches FeeOnly signers.
+func scopesMatch(scopes, filter transaction.WitnessScope) bool {
+	if filter == transaction.FeeOnly {
+		return scopes == transaction.FeeOnly
+	}
+	return scopes&filter == filter
+}
+
+// parseFilters converts subscription filter parameter (an object or an array
+// of alternative objects) into the list of filters for the given event.
+func parseFilters(event response.EventID, p *request.Param) ([]interface{}, error) {
+	var params []request.Param
+	if p.Type == request.ArrayT {
+		arr, err := p.GetArray()
+		if err != nil || len(arr) == 0 {
+			return nil, errors.New("empty filter list")
+		}
+		params = arr
+	} else {
+		params = []request.Param{*p}
+	}
+	filters := make([]interface{}, 0, len(params))
+	for i := range params {
+		switch params[i].Type {
+		case request.BlockFilterT, request.TxFilterT, request.NotificationFilterT, request.ExecutionFilterT:
+		default:
+			return nil, errors.New("invalid filter")
+		}
+		// Filter fields overlap, so parameter type is only a guess and
+		// the filter is decoded again using the stream-specific type.
+		data, err := json.Marshal(params[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		var filter interface{}
+		switch event {
+		case response.BlockEventID:
+			filter = new(request.BlockFilter)
+		case response.TransactionEventID, response.MempoolTransactionAddedEventID, response.MempoolTransactionRemovedEventID:
+			filter = new(request.TxFilter)
+		case response.NotificationEventID:
+			filter = new(request.NotificationFilter)
+		case response.ExecutionEventID:
+			filter = new(request.ExecutionFilter)
+		default:
+			return nil, errors.New("filters are not supported")
+		}
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		if err := d.Decode(filter); err != nil {
+			return nil, err
+		}
+		switch filt := filter.(type) {
+		case *request.BlockFilter:
+			filters = append(filters, *filt)
+		case *request.TxFilter:
+			filters = append(filters, *filt)
+		case *request.NotificationFilter:
+			filters = append(filters, *filt)
+		case *request.ExecutionFilter:
+			if filt.State != "" && filt.State != "HALT" && filt.State != "FAULT" {
+				return nil, errors.New("invalid execution state")
+			}
+			filters = append(filters, *filt)
+		}
+	}
+	return filters, nil
+}
@@ -9,9 +9,13 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -48,7 +52,11 @@ func getNotification(t *testing.T, respCh <-chan []byte) *response.Notification
 }
 
 func initCleanServerAndWSClient(t *testing.T) (*core.Blockchain, *Server, *websocket.Conn, chan []byte, *atomic.Bool) {
-	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	return initCleanServerAndWSClientWithConfig(t, nil)
+}
+
+func initCleanServerAndWSClientWithConfig(t *testing.T, f func(*rpc.Config)) (*core.Blockchain, *Server, *websocket.Conn, chan []byte, *atomic.Bool) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, f)
 
 	dialer := websocket.Dialer{HandshakeTimeout: time.Second}
 	url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
@@ -209,6 +217,49 @@ func TestFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "HALT", st)
 			},
 		},
+		"tx matching signer with scopes": {
+			params: `["transaction_added", {"signer":"` + goodSender.StringLE() + `", "scopes":"CalledByEntry"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.TransactionEventID, resp.Event)
+				var found bool
+				for _, s := range rmap["signers"].([]interface{}) {
+					signer := s.(map[string]interface{})
+					if signer["account"].(string) == "0x"+goodSender.StringLE() {
+						require.Contains(t, signer["scopes"].(string), "CalledByEntry")
+						found = true
+					}
+				}
+				require.True(t, found)
+			},
+		},
+		"notification matching alternatives": {
+			params: `["notification_from_execution", [{"contract":"00112233445566778899aabbccddeeff00112233", "name":"my_pretty_notification"}, {"contract":"` + testContractHash + `", "name":"my_pretty_notification"}]]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.NotificationEventID, resp.Event)
+				c := rmap["contract"].(string)
+				require.Equal(t, "0x"+testContractHash, c)
+				n := rmap["name"].(string)
+				require.Equal(t, "my_pretty_notification", n)
+			},
+		},
+		"execution matching contract": {
+			params: `["transaction_executed", {"state":"HALT", "contract":"` + testContractHash + `"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.ExecutionEventID, resp.Event)
+				st := rmap["vmstate"].(string)
+				require.Equal(t, "HALT", st)
+				var found bool
+				for _, n := range rmap["notifications"].([]interface{}) {
+					if n.(map[string]interface{})["contract"].(string) == "0x"+testContractHash {
+						found = true
+					}
+				}
+				require.True(t, found)
+			},
+		},
 		"tx non-matching": {
 			params: `["transaction_added", {"sender":"00112233445566778899aabbccddeeff00112233"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -221,6 +272,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
 			},
 		},
+		"tx non-matching alternatives": {
+			params: `["transaction_added", [{"sender":"00112233445566778899aabbccddeeff00112233"}, {"signer":"00112233445566778899aabbccddeeff00112233", "scopes":"CalledByEntry"}]]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for transaction")
+			},
+		},
+		"execution non-matching contract": {
+			params: `["transaction_executed", {"contract":"00112233445566778899aabbccddeeff00112233"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
+			},
+		},
 		"execution non-matching": {
 			params: `["transaction_executed", {"state":"FAULT"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -309,6 +372,87 @@ func TestFilteredBlockSubscriptions(t *testing.T) {
 	c.Close()
 }
 
+func TestFilteredBlockTransactionSubscriptions(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	goodSender := testchain.PrivateKeyByID(0).GetScriptHash()
+	blockSubID := callSubscribe(t, c, respMsgs, `["block_added", [{"primary":3}, {"transaction":{"sender":"`+goodSender.StringLE()+`"}}]]`)
+
+	var expected []uint32
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+		match := b.ConsensusData.PrimaryIndex == 3
+		for _, tx := range b.Transactions {
+			match = match || tx.Sender().Equals(goodSender)
+		}
+		if match {
+			expected = append(expected, b.Index)
+		}
+	}
+	require.NotEqual(t, 0, len(expected))
+
+	for _, index := range expected {
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.BlockEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, index, uint32(rmap["index"].(float64)))
+	}
+	callUnsubscribe(t, c, respMsgs, blockSubID)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
+func TestMempoolSubscriptions(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	goodSender := util.Uint160{1, 2, 3}
+	filter := `[{"sender":"` + goodSender.StringLE() + `"}]`
+	addedID := callSubscribe(t, c, respMsgs, `["mempool_transaction_added", `+filter+`]`)
+	removedID := callSubscribe(t, c, respMsgs, `["mempool_transaction_removed", `+filter+`]`)
+
+	mp := chain.GetMemPool()
+	var txs []*transaction.Transaction
+	for _, sender := range []util.Uint160{{3, 2, 1}, goodSender} {
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 1
+		tx.Signers = []transaction.Signer{{Account: sender}}
+		require.NoError(t, mp.Add(tx, &FeerStub{}))
+		txs = append(txs, tx)
+	}
+
+	resp := getNotification(t, respMsgs)
+	require.Equal(t, response.MempoolTransactionAddedEventID, resp.Event)
+	rmap := resp.Payload[0].(map[string]interface{})
+	require.Nil(t, rmap["reason"])
+	tx := rmap["transaction"].(map[string]interface{})
+	require.Equal(t, "0x"+txs[1].Hash().StringLE(), tx["hash"].(string))
+
+	// Both transactions expire with the next block.
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	resp = getNotification(t, respMsgs)
+	require.Equal(t, response.MempoolTransactionRemovedEventID, resp.Event)
+	rmap = resp.Payload[0].(map[string]interface{})
+	require.Equal(t, "expired", rmap["reason"].(string))
+	tx = rmap["transaction"].(map[string]interface{})
+	require.Equal(t, "0x"+txs[1].Hash().StringLE(), tx["hash"].(string))
+	require.Equal(t, 0, mp.Count())
+
+	// There is no history to replay for mempool events.
+	resp2 := callWSGetRaw(t, c, `{"jsonrpc": "2.0", "method": "subscribe", "params": ["mempool_transaction_added", null, 0], "id": 1}`, respMsgs)
+	require.NotNil(t, resp2.Error)
+
+	callUnsubscribe(t, c, respMsgs, addedID)
+	callUnsubscribe(t, c, respMsgs, removedID)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
 func TestMaxSubscriptions(t *testing.T) {
 	var subIDs = make([]string, 0)
 	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
@@ -351,6 +495,11 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"execution filter 3":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"name": "my_pretty_notification"}], "id": 1}`,
+		"block tx filter":        `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", {"transaction": {"state": "HALT"}}], "id": 1}`,
+		"tx scopes filter":       `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", {"scopes": "Everything"}], "id": 1}`,
+		"empty alternatives":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", []], "id": 1}`,
+		"bad alternative":        `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", [{"name": "my_pretty_notification"}, {"state": "HALT"}]], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
@@ -455,3 +604,120 @@ func testSubscriptionOverflow(t *testing.T) {
 	finishedFlag.CAS(false, true)
 	c.Close()
 }
+
+func TestSubscriptionReplay(t *testing.T) {
+	blocks := getTestBlocks(t)
+	half := len(blocks) / 2
+
+	check := func(t *testing.T, stream string, from uint32, expected []string, getID func(map[string]interface{}) string) {
+		chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		for _, b := range blocks[:half] {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		callSubscribe(t, c, respMsgs, fmt.Sprintf(`["%s", null, %d]`, stream, from))
+		// These are received as live events or as pending ones.
+		for _, b := range blocks[half:] {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		for i := range expected {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, stream, resp.Event.String())
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, expected[i], getID(rmap), i)
+		}
+		require.Equal(t, 0, len(respMsgs))
+		finishedFlag.CAS(false, true)
+		c.Close()
+	}
+	t.Run("blocks", func(t *testing.T) {
+		var expected []string
+		for _, b := range blocks[1:] {
+			expected = append(expected, b.Hash().StringLE())
+		}
+		check(t, "block_added", 2, expected, func(rmap map[string]interface{}) string {
+			return rmap["hash"].(string)[2:]
+		})
+	})
+	t.Run("executions", func(t *testing.T) {
+		var expected []string
+		for _, b := range blocks {
+			expected = append(expected, b.Hash().StringLE())
+			for _, tx := range b.Transactions {
+				expected = append(expected, tx.Hash().StringLE())
+			}
+		}
+		check(t, "transaction_executed", 0, expected, func(rmap map[string]interface{}) string {
+			return rmap["txid"].(string)[2:]
+		})
+	})
+	t.Run("depth limit", func(t *testing.T) {
+		chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClientWithConfig(t, func(cfg *rpc.Config) {
+			cfg.MaxReplayDepth = 2
+		})
+
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		for _, b := range blocks[:half] {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		callSubscribe(t, c, respMsgs, `["block_added", null, 1]`)
+		require.Equal(t, response.MissedEventID, getNotification(t, respMsgs).Event)
+		for _, b := range blocks[half:] {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		for _, b := range blocks[half:] {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, response.BlockEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, "0x"+b.Hash().StringLE(), rmap["hash"].(string))
+		}
+		require.Equal(t, 0, len(respMsgs))
+		finishedFlag.CAS(false, true)
+		c.Close()
+	})
+	t.Run("bad index", func(t *testing.T) {
+		chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		for _, params := range []string{`["block_added", null, -1]`, `["block_added", null, 2]`, `["block_added", null, "one"]`} {
+			resp := callWSGetRaw(t, c, fmt.Sprintf(`{"jsonrpc": "2.0","method": "subscribe","params": %s,"id": 1}`, params), respMsgs)
+			require.NotNil(t, resp.Error, params)
+		}
+		callSubscribe(t, c, respMsgs, `["block_added", null, 1]`)
+		finishedFlag.CAS(false, true)
+		c.Close()
+	})
+}
+
+func TestReplayPendingLimit(t *testing.T) {
+	msgs := make([]*websocket.PreparedMessage, 4)
+	for i := range msgs {
+		var err error
+		msgs[i], err = websocket.NewPreparedMessage(websocket.TextMessage, []byte{byte(i)})
+		require.NoError(t, err)
+	}
+	missed, err := newMissedEventMessage()
+	require.NoError(t, err)
+
+	r := newReplay(2)
+	r.add(msgs[0], 0)
+	r.add(msgs[1], 1)
+	r.setMarker(1)
+	r.add(msgs[2], 0)
+	pending, ok := r.takePending(1, missed)
+	require.True(t, ok)
+	require.Equal(t, []*websocket.PreparedMessage{missed, msgs[2]}, pending)
+
+	r.add(msgs[3], 0)
+	pending, ok = r.takePending(1, missed)
+	require.True(t, ok)
+	require.Equal(t, []*websocket.PreparedMessage{msgs[3]}, pending)
+	require.False(t, r.takeOverflow())
+}
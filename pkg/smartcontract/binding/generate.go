@@ -0,0 +1,593 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// Config contains parameters of the generated bindings.
+type Config struct {
+	// Package is the name of the generated package.
+	Package string
+	// Manifest is the contract manifest to generate bindings for.
+	Manifest *manifest.Manifest
+	// DebugInfo is an optional compiler debug info, if it's present Go
+	// names of the contract methods are taken from it.
+	DebugInfo *compiler.DebugInfo
+}
+
+// typeInfo describes how values of some smartcontract.ParamType are
+// represented in the generated code.
+type typeInfo struct {
+	// goType is the Go type used for arguments and results.
+	goType string
+	// argType is the Go type used for arguments if it differs from goType.
+	argType string
+	// zero is the zero value of goType.
+	zero string
+	// param is smartcontract.Parameter type constant (without package),
+	// empty for types passed as smartcontract.Parameter directly.
+	param string
+	// value is a format of argument's conversion to Parameter's Value.
+	value string
+	// emit is a format of argument's conversion to emit.Array element.
+	emit string
+	// fromItem is the name of the helper decoding stackitem.Item.
+	fromItem string
+	// fromParam is the name of the helper decoding smartcontract.Parameter.
+	fromParam string
+}
+
+var (
+	anyTypeInfo = typeInfo{
+		goType:  "stackitem.Item",
+		argType: "smartcontract.Parameter",
+		zero:    "nil",
+	}
+	typeInfos = map[smartcontract.ParamType]typeInfo{
+		smartcontract.BoolType: {
+			goType: "bool", zero: "false", param: "BoolType", value: "%s", emit: "%s",
+			fromItem: "itemToBool", fromParam: "paramToBool",
+		},
+		smartcontract.IntegerType: {
+			goType: "*big.Int", zero: "nil", param: "IntegerType", value: "%s", emit: "%s",
+			fromItem: "itemToBigInt", fromParam: "paramToBigInt",
+		},
+		smartcontract.ByteArrayType: {
+			goType: "[]byte", zero: "nil", param: "ByteArrayType", value: "%s", emit: "%s",
+			fromItem: "itemToBytes", fromParam: "paramToBytes",
+		},
+		smartcontract.SignatureType: {
+			goType: "[]byte", zero: "nil", param: "SignatureType", value: "%s", emit: "%s",
+			fromItem: "itemToBytes", fromParam: "paramToBytes",
+		},
+		smartcontract.StringType: {
+			goType: "string", zero: `""`, param: "StringType", value: "%s", emit: "%s",
+			fromItem: "itemToString", fromParam: "paramToString",
+		},
+		smartcontract.Hash160Type: {
+			goType: "util.Uint160", zero: "util.Uint160{}", param: "Hash160Type", value: "%s", emit: "%s",
+			fromItem: "itemToUint160", fromParam: "paramToUint160",
+		},
+		smartcontract.Hash256Type: {
+			goType: "util.Uint256", zero: "util.Uint256{}", param: "Hash256Type", value: "%s", emit: "%s.BytesBE()",
+			fromItem: "itemToUint256", fromParam: "paramToUint256",
+		},
+		smartcontract.PublicKeyType: {
+			goType: "*keys.PublicKey", zero: "nil", param: "PublicKeyType", value: "%s.Bytes()", emit: "%s.Bytes()",
+			fromItem: "itemToPublicKey", fromParam: "paramToPublicKey",
+		},
+	}
+
+	// helpers contains the code of decoding functions used by the generated
+	// methods and events. Null values are decoded as zero values for hashes,
+	// keys and byte arrays (NEP5 transfer events have null addresses for
+	// minting and burning).
+	helpers = map[string]string{
+		"itemToBool": `func itemToBool(item stackitem.Item) (bool, error) {
+	return item.TryBool()
+}`,
+		"itemToBigInt": `func itemToBigInt(item stackitem.Item) (*big.Int, error) {
+	return item.TryInteger()
+}`,
+		"itemToBytes": `func itemToBytes(item stackitem.Item) ([]byte, error) {
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, nil
+	}
+	return item.TryBytes()
+}`,
+		"itemToString": `func itemToString(item stackitem.Item) (string, error) {
+	bs, err := item.TryBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}`,
+		"itemToUint160": `func itemToUint160(item stackitem.Item) (util.Uint160, error) {
+	if _, ok := item.(stackitem.Null); ok {
+		return util.Uint160{}, nil
+	}
+	bs, err := item.TryBytes()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(bs)
+}`,
+		"itemToUint256": `func itemToUint256(item stackitem.Item) (util.Uint256, error) {
+	if _, ok := item.(stackitem.Null); ok {
+		return util.Uint256{}, nil
+	}
+	bs, err := item.TryBytes()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(bs)
+}`,
+		"itemToPublicKey": `func itemToPublicKey(item stackitem.Item) (*keys.PublicKey, error) {
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, nil
+	}
+	bs, err := item.TryBytes()
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(bs, elliptic.P256())
+}`,
+		"paramToBool": `func paramToBool(p smartcontract.Parameter) (bool, error) {
+	v, ok := p.Value.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return v, nil
+}`,
+		"paramToBigInt": `func paramToBigInt(p smartcontract.Parameter) (*big.Int, error) {
+	switch v := p.Value.(type) {
+	case int64:
+		return big.NewInt(v), nil
+	case *big.Int:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+}`,
+		"paramToBytes": `func paramToBytes(p smartcontract.Parameter) ([]byte, error) {
+	if p.Type == smartcontract.AnyType {
+		return nil, nil
+	}
+	v, ok := p.Value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return v, nil
+}`,
+		"paramToString": `func paramToString(p smartcontract.Parameter) (string, error) {
+	v, ok := p.Value.([]byte)
+	if !ok {
+		return "", fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return string(v), nil
+}`,
+		"paramToUint160": `func paramToUint160(p smartcontract.Parameter) (util.Uint160, error) {
+	if p.Type == smartcontract.AnyType {
+		return util.Uint160{}, nil
+	}
+	v, ok := p.Value.([]byte)
+	if !ok {
+		return util.Uint160{}, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return util.Uint160DecodeBytesBE(v)
+}`,
+		"paramToUint256": `func paramToUint256(p smartcontract.Parameter) (util.Uint256, error) {
+	if p.Type == smartcontract.AnyType {
+		return util.Uint256{}, nil
+	}
+	v, ok := p.Value.([]byte)
+	if !ok {
+		return util.Uint256{}, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return util.Uint256DecodeBytesBE(v)
+}`,
+		"paramToPublicKey": `func paramToPublicKey(p smartcontract.Parameter) (*keys.PublicKey, error) {
+	if p.Type == smartcontract.AnyType {
+		return nil, nil
+	}
+	v, ok := p.Value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s parameter", p.Type)
+	}
+	return keys.NewPublicKeyFromBytes(v, elliptic.P256())
+}`,
+	}
+
+	// imports is a list of packages that can be used by the generated code.
+	imports = []struct{ name, path string }{
+		{"elliptic", "crypto/elliptic"},
+		{"errors", "errors"},
+		{"fmt", "fmt"},
+		{"big", "math/big"},
+		{"transaction", "github.com/nspcc-dev/neo-go/pkg/core/transaction"},
+		{"keys", "github.com/nspcc-dev/neo-go/pkg/crypto/keys"},
+		{"io", "github.com/nspcc-dev/neo-go/pkg/io"},
+		{"client", "github.com/nspcc-dev/neo-go/pkg/rpc/client"},
+		{"result", "github.com/nspcc-dev/neo-go/pkg/rpc/response/result"},
+		{"smartcontract", "github.com/nspcc-dev/neo-go/pkg/smartcontract"},
+		{"util", "github.com/nspcc-dev/neo-go/pkg/util"},
+		{"emit", "github.com/nspcc-dev/neo-go/pkg/vm/emit"},
+		{"opcode", "github.com/nspcc-dev/neo-go/pkg/vm/opcode"},
+		{"stackitem", "github.com/nspcc-dev/neo-go/pkg/vm/stackitem"},
+		{"wallet", "github.com/nspcc-dev/neo-go/pkg/wallet"},
+	}
+
+	// reservedNames are the names of local variables used in the generated
+	// code, arguments can't use them (as well as imported package names).
+	reservedNames = map[string]bool{
+		"acc": true, "c": true, "err": true, "gas": true, "res": true, "w": true,
+	}
+)
+
+type (
+	paramTmpl struct {
+		Name      string
+		GoName    string
+		Type      string
+		Value     string
+		Emit      string
+		FromParam string
+	}
+
+	methodTmpl struct {
+		Name       string
+		GoName     string
+		Params     []paramTmpl
+		ReturnType string
+		Zero       string
+		FromItem   string
+		Assert     bool
+	}
+
+	eventTmpl struct {
+		Name   string
+		GoName string
+		Params []paramTmpl
+		// NeedErr is true if at least one parameter needs decoding.
+		NeedErr bool
+	}
+
+	contractTmpl struct {
+		Package string
+		Hash    string
+		Safe    []methodTmpl
+		Unsafe  []methodTmpl
+		Events  []eventTmpl
+		Helpers []string
+	}
+)
+
+const bodyTmpl = `
+// Hash is the hash of the contract from its manifest.
+var Hash, _ = util.Uint160DecodeStringBE("{{.Hash}}")
+
+// Contract is a wrapper for the contract methods.
+type Contract struct {
+	client *client.Client
+	hash   util.Uint160
+}
+
+// New creates a new Contract wrapper using given RPC client and contract hash.
+func New(c *client.Client, hash util.Uint160) *Contract {
+	return &Contract{client: c, hash: hash}
+}
+{{range .Safe}}
+// {{.GoName}} invokes ` + "`{{.Name}}`" + ` method of the contract.
+func (c *Contract) {{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.GoName}} {{$p.Type}}{{end}}) {{if .ReturnType}}({{.ReturnType}}, error){{else}}error{{end}} {
+	res, err := c.client.InvokeFunction(c.hash, {{printf "%q" .Name}}, []smartcontract.Parameter{
+	{{- if .Params}}
+		{{- range .Params}}
+		{{.Value}},
+		{{- end}}
+	{{end -}}
+	}, nil)
+	if err != nil {
+		return {{if .ReturnType}}{{.Zero}}, {{end}}err
+	} else if res.State != "HALT"{{if .ReturnType}} || len(res.Stack) == 0{{end}} {
+		return {{if .ReturnType}}{{.Zero}}, {{end}}errors.New("invalid VM state")
+	}
+	{{- if not .ReturnType}}
+	return nil
+	{{- else if .FromItem}}
+	return {{.FromItem}}(res.Stack[len(res.Stack)-1])
+	{{- else}}
+	return res.Stack[len(res.Stack)-1], nil
+	{{- end}}
+}
+{{end}}
+{{- range .Unsafe}}
+// {{.GoName}} creates a transaction invoking ` + "`{{.Name}}`" + ` method of the
+// contract{{if .Assert}} (its result is checked with ASSERT){{end}}. The returned transaction is not signed.
+func (c *Contract) {{.GoName}}(acc *wallet.Account, gas int64{{range .Params}}, {{.GoName}} {{.Type}}{{end}}) (*transaction.Transaction, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, c.hash, {{printf "%q" .Name}}{{range .Params}}, {{.Emit}}{{end}})
+	{{- if .Assert}}
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	{{- end}}
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return c.client.CreateTxFromScript(w.Bytes(), acc, -1, gas)
+}
+{{end}}
+{{- range .Events}}
+// {{.GoName}} represents ` + "`{{.Name}}`" + ` event emitted by the contract.
+type {{.GoName}} struct {
+	{{- range .Params}}
+	{{.GoName}} {{.Type}}
+	{{- end}}
+}
+
+// {{.GoName}}FromNotification converts the notification into {{.GoName}}, it
+// doesn't check the contract that emitted the notification.
+func {{.GoName}}FromNotification(ne *result.NotificationEvent) (*{{.GoName}}, error) {
+	if ne.Name != {{printf "%q" .Name}} {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	params, ok := ne.Item.Value.([]smartcontract.Parameter)
+	if ne.Item.Type != smartcontract.ArrayType || !ok {
+		return nil, errors.New("event parameters are not an array")
+	}
+	if len(params) != {{len .Params}} {
+		return nil, fmt.Errorf("wrong number of event parameters: %d", len(params))
+	}
+	var ev = new({{.GoName}})
+	{{- if .NeedErr}}
+	var err error
+	{{- end}}
+	{{- range $i, $p := .Params}}
+	{{- if $p.FromParam}}
+	ev.{{$p.GoName}}, err = {{$p.FromParam}}(params[{{$i}}])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter: %w", {{printf "%q" $p.Name}}, err)
+	}
+	{{- else}}
+	ev.{{$p.GoName}} = params[{{$i}}]
+	{{- end}}
+	{{- end}}
+	return ev, nil
+}
+{{end}}
+{{- range .Helpers}}
+{{.}}
+{{end}}`
+
+var srcTmpl = template.Must(template.New("binding").Parse(bodyTmpl))
+
+// Generate writes Go source code of the contract bindings to w. Safe methods
+// of the contract become methods invoking them via `invokefunction` RPC and
+// decoding the result, other methods become transaction builders and every
+// event gets a structure that can be created from the notification.
+func Generate(cfg Config, w io.Writer) error {
+	if cfg.Manifest == nil {
+		return errors.New("no manifest")
+	}
+	if !token.IsIdentifier(cfg.Package) {
+		return fmt.Errorf("invalid package name: %q", cfg.Package)
+	}
+	var (
+		ctr  = newContractTmpl(cfg)
+		body bytes.Buffer
+	)
+	if err := srcTmpl.Execute(&body, ctr); err != nil {
+		return err
+	}
+	var src bytes.Buffer
+	src.WriteString("// Code generated by neo-go contract generate-wrapper; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "// Package %s contains RPC wrappers for the contract.\n", cfg.Package)
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", cfg.Package)
+	var std = true
+	for _, imp := range imports {
+		if !regexp.MustCompile(`\b` + imp.name + `\.`).Match(body.Bytes()) {
+			continue
+		}
+		if std && strings.Contains(imp.path, ".") {
+			src.WriteString("\n")
+			std = false
+		}
+		fmt.Fprintf(&src, "\t%q\n", imp.path)
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+
+	res, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	_, err = w.Write(res)
+	return err
+}
+
+func newContractTmpl(cfg Config) *contractTmpl {
+	var (
+		m       = cfg.Manifest
+		ctr     = &contractTmpl{Package: cfg.Package, Hash: m.ABI.Hash.StringBE()}
+		needed  = make(map[string]bool)
+		used    = map[string]bool{"Contract": true, "Hash": true, "New": true}
+		goNames = make(map[string]string)
+	)
+	if cfg.DebugInfo != nil {
+		for _, mi := range cfg.DebugInfo.Methods {
+			if token.IsIdentifier(mi.ID) && token.IsExported(mi.ID) {
+				goNames[mi.Name.Name] = mi.ID
+			}
+		}
+	}
+	for _, mm := range m.ABI.Methods {
+		if strings.HasPrefix(mm.Name, "_") {
+			continue // Special methods like `_deploy` are not to be called directly.
+		}
+		goName, ok := goNames[mm.Name]
+		if !ok {
+			goName = exportedName(mm.Name)
+		}
+		mt := methodTmpl{
+			Name:   mm.Name,
+			Params: newParams(mm.Parameters),
+			Assert: mm.ReturnType == smartcontract.BoolType,
+		}
+		safe := m.SafeMethods.Contains(mm.Name)
+		if safe {
+			mt.GoName = uniqueName(used, goName)
+		} else {
+			mt.GoName = uniqueName(used, "Create"+goName+"Tx")
+		}
+		for i := range mt.Params {
+			ti := getTypeInfo(mm.Parameters[i].Type)
+			p := &mt.Params[i]
+			switch {
+			case ti.param == "":
+				p.Value = p.GoName
+				p.Emit = p.GoName
+				if !safe {
+					p.Type = "interface{}"
+				}
+			default:
+				p.Value = fmt.Sprintf("{Type: smartcontract.%s, Value: %s}", ti.param, fmt.Sprintf(ti.value, p.GoName))
+				p.Emit = fmt.Sprintf(ti.emit, p.GoName)
+			}
+		}
+		if !safe {
+			ctr.Unsafe = append(ctr.Unsafe, mt)
+			continue
+		}
+		if mm.ReturnType != smartcontract.VoidType {
+			ti := getTypeInfo(mm.ReturnType)
+			mt.ReturnType = ti.goType
+			mt.Zero = ti.zero
+			mt.FromItem = ti.fromItem
+			if ti.fromItem != "" {
+				needed[ti.fromItem] = true
+			}
+		}
+		ctr.Safe = append(ctr.Safe, mt)
+	}
+	for _, e := range m.ABI.Events {
+		et := eventTmpl{
+			Name:   e.Name,
+			GoName: uniqueName(used, exportedName(e.Name)+"Event"),
+			Params: newParams(e.Parameters),
+		}
+		fields := make(map[string]bool)
+		for i := range et.Params {
+			p := &et.Params[i]
+			p.GoName = uniqueName(fields, exportedName(p.Name))
+			if p.FromParam != "" {
+				et.NeedErr = true
+				needed[p.FromParam] = true
+			}
+		}
+		ctr.Events = append(ctr.Events, et)
+	}
+	names := make([]string, 0, len(needed))
+	for name := range needed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ctr.Helpers = append(ctr.Helpers, helpers[name])
+	}
+	return ctr
+}
+
+// newParams converts manifest parameters into template ones with argument
+// names and Go types set.
+func newParams(ps []manifest.Parameter) []paramTmpl {
+	var (
+		res  = make([]paramTmpl, len(ps))
+		used = make(map[string]bool)
+	)
+	for name := range reservedNames {
+		used[name] = true
+	}
+	for _, imp := range imports {
+		used[imp.name] = true
+	}
+	for i := range ps {
+		ti := getTypeInfo(ps[i].Type)
+		res[i] = paramTmpl{
+			Name:      ps[i].Name,
+			GoName:    uniqueName(used, argName(ps[i].Name)),
+			Type:      ti.goType,
+			FromParam: ti.fromParam,
+		}
+		if ti.argType != "" {
+			res[i].Type = ti.argType
+		}
+	}
+	return res
+}
+
+func getTypeInfo(t smartcontract.ParamType) typeInfo {
+	if ti, ok := typeInfos[t]; ok {
+		return ti
+	}
+	return anyTypeInfo
+}
+
+// uniqueName returns a name that is not yet in used (adding numeric suffix
+// to it if needed) and marks it as used.
+func uniqueName(used map[string]bool, name string) string {
+	res := name
+	for i := 1; used[res]; i++ {
+		res = fmt.Sprintf("%s%d", name, i)
+	}
+	used[res] = true
+	return res
+}
+
+// exportedName converts arbitrary string into an exported Go identifier,
+// `hello world` becomes `HelloWorld`.
+func exportedName(s string) string {
+	var (
+		b     strings.Builder
+		upper = true
+	)
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	res := b.String()
+	if res == "" || !unicode.IsUpper([]rune(res)[0]) {
+		res = "X" + res
+	}
+	return res
+}
+
+// argName converts arbitrary string into an unexported Go identifier
+// suitable for function argument.
+func argName(s string) string {
+	name := []rune(exportedName(s))
+	name[0] = unicode.ToLower(name[0])
+	res := string(name)
+	if token.IsKeyword(res) {
+		res += "Arg"
+	}
+	return res
+}
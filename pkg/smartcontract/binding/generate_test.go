@@ -0,0 +1,196 @@
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	tokenbinding "github.com/nspcc-dev/neo-go/pkg/smartcontract/binding/testdata/token"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func testManifest() *manifest.Manifest {
+	m := manifest.NewManifest(util.Uint160{1, 2, 3})
+	m.ABI.Methods = []manifest.Method{
+		{Name: "_deploy", ReturnType: smartcontract.VoidType},
+		{Name: "balanceOf", ReturnType: smartcontract.IntegerType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("account", smartcontract.Hash160Type),
+		}},
+		{Name: "symbol", ReturnType: smartcontract.StringType},
+		{Name: "getKey", ReturnType: smartcontract.PublicKeyType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("type", smartcontract.IntegerType),
+			manifest.NewParameter("data", smartcontract.ArrayType),
+		}},
+		{Name: "transfer", ReturnType: smartcontract.BoolType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+		}},
+		{Name: "destroy", ReturnType: smartcontract.VoidType},
+	}
+	m.ABI.Events = []manifest.Event{
+		{Name: "transfer", Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+		}},
+		{Name: "Hello world!", Parameters: []manifest.Parameter{
+			manifest.NewParameter("args", smartcontract.ArrayType),
+		}},
+	}
+	m.SafeMethods.Add("balanceOf")
+	m.SafeMethods.Add("symbol")
+	m.SafeMethods.Add("getKey")
+	return m
+}
+
+func generate(t *testing.T, cfg Config) string {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Generate(cfg, buf))
+	_, err := parser.ParseFile(token.NewFileSet(), "", buf.Bytes(), 0)
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("invalid config", func(t *testing.T) {
+		require.Error(t, Generate(Config{Package: "token"}, new(bytes.Buffer)))
+		require.Error(t, Generate(Config{Package: "1token", Manifest: testManifest()}, new(bytes.Buffer)))
+	})
+	t.Run("manifest", func(t *testing.T) {
+		src := generate(t, Config{Package: "token", Manifest: testManifest()})
+		require.Contains(t, src, "package token\n")
+		require.Contains(t, src, `util.Uint160DecodeStringBE("`+util.Uint160{1, 2, 3}.StringBE()+`")`)
+		require.NotContains(t, src, "_deploy")
+		require.Contains(t, src, "func (c *Contract) BalanceOf(account util.Uint160) (*big.Int, error) {")
+		require.Contains(t, src, "{Type: smartcontract.Hash160Type, Value: account},")
+		require.Contains(t, src, "func (c *Contract) Symbol() (string, error) {")
+		require.Contains(t, src, "func (c *Contract) GetKey(typeArg *big.Int, data smartcontract.Parameter) (*keys.PublicKey, error) {")
+		require.Contains(t, src, "func (c *Contract) CreateTransferTx(acc *wallet.Account, gas int64, from util.Uint160, to util.Uint160, amount *big.Int) (*transaction.Transaction, error) {")
+		require.Contains(t, src, `emit.AppCallWithOperationAndArgs(w.BinWriter, c.hash, "transfer", from, to, amount)`)
+		require.Contains(t, src, "emit.Opcode(w.BinWriter, opcode.ASSERT)")
+		require.Contains(t, src, "func (c *Contract) CreateDestroyTx(acc *wallet.Account, gas int64) (*transaction.Transaction, error) {")
+		require.Contains(t, src, "type TransferEvent struct {")
+		require.Contains(t, src, "func TransferEventFromNotification(ne *result.NotificationEvent) (*TransferEvent, error) {")
+		require.Contains(t, src, "type HelloWorldEvent struct {\n\tArgs smartcontract.Parameter\n}")
+		require.Contains(t, src, "func itemToBigInt(")
+		require.Contains(t, src, "func paramToUint160(")
+		require.NotContains(t, src, "func itemToBool(")
+	})
+	t.Run("debug info", func(t *testing.T) {
+		di := &compiler.DebugInfo{
+			Methods: []compiler.MethodDebugInfo{{
+				ID:   "BalanceOfAccount",
+				Name: compiler.DebugMethodName{Namespace: "token", Name: "balanceOf"},
+			}},
+		}
+		src := generate(t, Config{Package: "token", Manifest: testManifest(), DebugInfo: di})
+		require.Contains(t, src, "func (c *Contract) BalanceOfAccount(account util.Uint160) (*big.Int, error) {")
+	})
+	t.Run("everything is safe", func(t *testing.T) {
+		m := testManifest()
+		m.SafeMethods = manifest.WildStrings{}
+		src := generate(t, Config{Package: "token", Manifest: m})
+		require.Contains(t, src, "func (c *Contract) Transfer(from util.Uint160, to util.Uint160, amount *big.Int) (bool, error) {")
+		require.Contains(t, src, "func (c *Contract) Destroy() error {")
+		require.NotContains(t, src, "wallet.Account")
+	})
+}
+
+// TestGeneratedCode checks that testdata/token package is up to date with
+// the generator, so the code generated for testManifest is compiled with the
+// test and can be run.
+func TestGeneratedCode(t *testing.T) {
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "token", "token.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), generate(t, Config{Package: "token", Manifest: testManifest()}),
+		"testdata/token/token.go is outdated")
+
+	t.Run("transfer event", func(t *testing.T) {
+		acc := util.Uint160{4, 5, 6}
+		amount, _ := new(big.Int).SetString("100000000000000000000", 10)
+		// Minting and burning transfers have null addresses.
+		for _, items := range [][]stackitem.Item{
+			{stackitem.NewByteArray(acc.BytesBE()), stackitem.NewByteArray(acc.BytesBE())},
+			{stackitem.Null{}, stackitem.NewByteArray(acc.BytesBE())},
+			{stackitem.NewByteArray(acc.BytesBE()), stackitem.Null{}},
+		} {
+			ne := result.StateEventToResultNotification(state.NotificationEvent{
+				ScriptHash: tokenbinding.Hash,
+				Name:       "transfer",
+				Item:       stackitem.NewArray(append(items, stackitem.NewBigInteger(amount))),
+			})
+			data, err := json.Marshal(ne)
+			require.NoError(t, err)
+			var actual result.NotificationEvent
+			require.NoError(t, json.Unmarshal(data, &actual))
+
+			ev, err := tokenbinding.TransferEventFromNotification(&actual)
+			require.NoError(t, err)
+			for i, h := range []util.Uint160{ev.From, ev.To} {
+				if _, ok := items[i].(stackitem.Null); ok {
+					require.Equal(t, util.Uint160{}, h)
+				} else {
+					require.Equal(t, acc, h)
+				}
+			}
+			require.Equal(t, amount, ev.Amount)
+		}
+		_, err := tokenbinding.TransferEventFromNotification(&result.NotificationEvent{Name: "transfer"})
+		require.Error(t, err)
+	})
+	t.Run("null result", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"script":"","state":"HALT","gasconsumed":"1","stack":[{"type":"Any"}]}}`))
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+		c, err := client.New(context.TODO(), srv.URL, client.Options{})
+		require.NoError(t, err)
+		key, err := tokenbinding.New(c, tokenbinding.Hash).GetKey(big.NewInt(1), smartcontract.Parameter{Type: smartcontract.ArrayType, Value: []smartcontract.Parameter{}})
+		require.NoError(t, err)
+		require.Nil(t, key)
+	})
+	t.Run("big integer result", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"script":"","state":"HALT","gasconsumed":"1","stack":[{"type":"Integer","value":"100000000000000000000"}]}}`))
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+		c, err := client.New(context.TODO(), srv.URL, client.Options{})
+		require.NoError(t, err)
+		balance, err := tokenbinding.New(c, tokenbinding.Hash).BalanceOf(util.Uint160{4, 5, 6})
+		require.NoError(t, err)
+		require.Equal(t, "100000000000000000000", balance.String())
+	})
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "HelloWorld", exportedName("Hello world!"))
+	require.Equal(t, "BalanceOf", exportedName("balanceOf"))
+	require.Equal(t, "X1st", exportedName("1st"))
+	require.Equal(t, "X", exportedName("!"))
+	require.Equal(t, "amount", argName("amount"))
+	require.Equal(t, "typeArg", argName("type"))
+
+	used := map[string]bool{"a": true}
+	require.Equal(t, "a1", uniqueName(used, "a"))
+	require.Equal(t, "a2", uniqueName(used, "a"))
+	require.Equal(t, "b", uniqueName(used, "b"))
+}
@@ -13,9 +13,13 @@ func ParameterFromStackItem(i stackitem.Item, seen map[stackitem.Item]bool) Para
 	case stackitem.Null, *stackitem.Pointer:
 		return NewParameter(AnyType)
 	case *stackitem.BigInteger:
+		bi := i.Value().(*big.Int)
+		if !bi.IsInt64() {
+			return Parameter{Type: IntegerType, Value: bi}
+		}
 		return Parameter{
 			Type:  IntegerType,
-			Value: i.Value().(*big.Int).Int64(),
+			Value: bi.Int64(),
 		}
 	case *stackitem.Bool:
 		return Parameter{
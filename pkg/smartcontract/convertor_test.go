@@ -22,6 +22,10 @@ var toContractParameterTestCases = []struct {
 			{Type: BoolType, Value: true},
 		}},
 	},
+	{
+		input:  stackitem.NewBigInteger(new(big.Int).Lsh(big.NewInt(1), 70)),
+		result: Parameter{Type: IntegerType, Value: new(big.Int).Lsh(big.NewInt(1), 70)},
+	},
 	{
 		input:  stackitem.NewBool(false),
 		result: Parameter{Type: BoolType, Value: false},
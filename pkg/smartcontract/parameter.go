@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"math/bits"
 	"strconv"
 	"strings"
@@ -27,7 +28,8 @@ const (
 	NoProperties               = 0
 )
 
-// Parameter represents a smart contract parameter.
+// Parameter represents a smart contract parameter. IntegerType values are
+// int64 unless they don't fit into it, then they're *big.Int.
 type Parameter struct {
 	// Type of the parameter.
 	Type ParamType `json:"type"`
@@ -66,12 +68,18 @@ func (p Parameter) MarshalJSON() ([]byte, error) {
 	case BoolType, StringType, Hash160Type, Hash256Type:
 		resultRawValue, resultErr = json.Marshal(p.Value)
 	case IntegerType:
-		val, ok := p.Value.(int64)
-		if !ok {
+		var valStr string
+		switch val := p.Value.(type) {
+		case int64:
+			valStr = strconv.FormatInt(val, 10)
+		case *big.Int:
+			valStr = val.String()
+		default:
 			resultErr = errors.New("invalid integer value")
+		}
+		if resultErr != nil {
 			break
 		}
-		valStr := strconv.FormatInt(val, 10)
 		resultRawValue = json.RawMessage(`"` + valStr + `"`)
 	case PublicKeyType, ByteArrayType, SignatureType:
 		if p.Value == nil {
@@ -150,10 +158,16 @@ func (p *Parameter) UnmarshalJSON(data []byte) (err error) {
 		if err = json.Unmarshal(r.Value, &s); err != nil {
 			return
 		}
-		if i, err = strconv.ParseInt(s, 10, 64); err != nil {
+		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
+			p.Value = i
+			return
+		}
+		// integers that don't fit into int64 are stored as *big.Int
+		bi, ok := new(big.Int).SetString(s, 10)
+		if !ok {
 			return
 		}
-		p.Value = i
+		p.Value, err = bi, nil
 	case ArrayType:
 		// https://github.com/neo-project/neo/blob/3d59ecca5a8deb057bdad94b3028a6d5e25ac088/neo/Network/RPC/RpcServer.cs#L67
 		var rs []Parameter
@@ -203,7 +217,12 @@ func (p *Parameter) EncodeBinary(w *io.BinWriter) {
 	case StringType:
 		w.WriteString(p.Value.(string))
 	case IntegerType:
-		w.WriteU64LE(uint64(p.Value.(int64)))
+		val, ok := p.Value.(int64)
+		if !ok {
+			w.Err = errors.New("integer doesn't fit into int64")
+			return
+		}
+		w.WriteU64LE(uint64(val))
 	case ArrayType:
 		w.WriteArray(p.Value.([]Parameter))
 	case MapType:
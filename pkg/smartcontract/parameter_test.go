@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -162,6 +163,17 @@ func TestParam_MarshalJSON(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 
+	t.Run("big integer", func(t *testing.T) {
+		p := Parameter{Type: IntegerType, Value: new(big.Int).Lsh(big.NewInt(1), 70)}
+		res, err := json.Marshal(p)
+		require.NoError(t, err)
+		require.Equal(t, `{"type":"Integer","value":"1180591620717411303424"}`, string(res))
+
+		var actual Parameter
+		require.NoError(t, json.Unmarshal(res, &actual))
+		require.Equal(t, p, actual)
+	})
+
 	for _, input := range marshalJSONErrorCases {
 		_, err := json.Marshal(&input)
 		assert.Error(t, err)
@@ -184,6 +196,10 @@ var unmarshalJSONTestCases = []struct {
 		input:  `{"type":"Integer","value":"12345"}`,
 		result: Parameter{Type: IntegerType, Value: int64(12345)},
 	},
+	{
+		input:  `{"type":"Integer","value":"1180591620717411303424"}`,
+		result: Parameter{Type: IntegerType, Value: new(big.Int).Lsh(big.NewInt(1), 70)},
+	},
 	{
 		input:  `{"type":"ByteString","value":"` + hexToBase64("010203") + `"}`,
 		result: Parameter{Type: ByteArrayType, Value: []byte{0x01, 0x02, 0x03}},
@@ -498,6 +514,12 @@ func TestEncodeDecodeBinary(t *testing.T) {
 		testserdes.EncodeDecodeBinary(t, &tc.input, new(Parameter))
 	}
 
+	t.Run("big integer", func(t *testing.T) {
+		p := Parameter{Type: IntegerType, Value: new(big.Int).Lsh(big.NewInt(1), 70)}
+		_, err := testserdes.EncodeBinary(&p)
+		require.Error(t, err)
+	})
+
 	t.Run("unknown", func(t *testing.T) {
 		p := Parameter{Type: UnknownType}
 		_, err := testserdes.EncodeBinary(&p)
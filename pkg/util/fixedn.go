@@ -0,0 +1,72 @@
+package util
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var errInvalidFixedN = errors.New("fixed-point number must satisfy following regex -?\\d+(\\.\\d+)? with no more fractional digits than its precision")
+
+// BigFixedNFromString parses s which must be a fixed point number with
+// precision 10^-d returning it as an integer multiplied by 10^d. Unlike
+// FixedNFromString it's not limited by int64 range.
+func BigFixedNFromString(s string, precision int) (*big.Int, error) {
+	if precision < 0 {
+		return nil, errors.New("negative precision")
+	}
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	parts := strings.SplitN(s, ".", 2)
+	if !isDecimalDigits(parts[0]) {
+		return nil, errInvalidFixedN
+	}
+	var fp string
+	if len(parts) == 2 {
+		fp = parts[1]
+		if !isDecimalDigits(fp) || len(fp) > precision {
+			return nil, errInvalidFixedN
+		}
+	}
+	n, _ := new(big.Int).SetString(parts[0]+fp+strings.Repeat("0", precision-len(fp)), 10)
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// BigFixedNToString returns n which is an integer representation of a fixed
+// point number with precision 10^-d as a string. The string has no
+// fractional part if n is a multiple of 10^d and has all d fractional digits
+// otherwise.
+func BigFixedNToString(n *big.Int, precision int) string {
+	if precision <= 0 {
+		return n.String()
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(n), pow, new(big.Int))
+	var sign string
+	if n.Sign() < 0 {
+		sign = "-"
+	}
+	if r.Sign() == 0 {
+		return sign + q.String()
+	}
+	fs := r.String()
+	return sign + q.String() + "." + strings.Repeat("0", precision-len(fs)) + fs
+}
+
+// isDecimalDigits checks that s is a non-empty string of decimal digits.
+func isDecimalDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := range s {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
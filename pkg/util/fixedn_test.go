@@ -0,0 +1,62 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigFixedNFromString(t *testing.T) {
+	testCases := []struct {
+		s         string
+		precision int
+		expected  string
+	}{
+		{"123.456", 3, "123456"},
+		{"123.456", 4, "1234560"},
+		{"123", 2, "12300"},
+		{"-0.5", 1, "-5"},
+		{"0.000000000000000001", 18, "1"},
+		{"100000000000", 18, "100000000000000000000000000000"},
+	}
+	for _, tc := range testCases {
+		n, err := BigFixedNFromString(tc.s, tc.precision)
+		require.NoError(t, err, tc.s)
+		require.Equal(t, tc.expected, n.String(), tc.s)
+	}
+
+	for _, s := range []string{"123.456", "", ".5", "1.", "1.2.3", "+1", "--1", "1e5", "0x10"} {
+		_, err := BigFixedNFromString(s, 2)
+		require.Error(t, err, s)
+	}
+	_, err := BigFixedNFromString("1", -1)
+	require.Error(t, err)
+}
+
+func TestBigFixedNToString(t *testing.T) {
+	testCases := []struct {
+		n         string
+		precision int
+		expected  string
+	}{
+		{"123456", 3, "123.456"},
+		{"1234560", 4, "123.4560"},
+		{"12300", 2, "123"},
+		{"-5", 1, "-0.5"},
+		{"-15", 1, "-1.5"},
+		{"1", 18, "0.000000000000000001"},
+		{"100000000000000000000000000000", 18, "100000000000"},
+		{"42", 0, "42"},
+	}
+	for _, tc := range testCases {
+		n, ok := new(big.Int).SetString(tc.n, 10)
+		require.True(t, ok)
+		s := BigFixedNToString(n, tc.precision)
+		require.Equal(t, tc.expected, s)
+
+		back, err := BigFixedNFromString(s, tc.precision)
+		require.NoError(t, err)
+		require.Equal(t, n.String(), back.String())
+	}
+}
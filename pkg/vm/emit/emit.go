@@ -56,20 +56,44 @@ func Int(w *io.BinWriter, i int64) {
 		val := opcode.Opcode(int(opcode.PUSH1) - 1 + int(i))
 		Opcode(w, val)
 	default:
-		buf := bigint.ToPreallocatedBytes(big.NewInt(i), make([]byte, 0, 32))
-		// l != 0 becase of switch
-		padSize := byte(8 - bits.LeadingZeros8(byte(len(buf)-1)))
-		Opcode(w, opcode.PUSHINT8+opcode.Opcode(padSize))
-		w.WriteBytes(padRight(1<<padSize, buf))
+		bigInt(w, big.NewInt(i))
 	}
 }
 
+// BigInt emits big-integer to the given buffer, it can't be longer than 32
+// bytes.
+func BigInt(w *io.BinWriter, n *big.Int) {
+	if n.IsInt64() {
+		Int(w, n.Int64())
+		return
+	}
+	bigInt(w, n)
+}
+
+func bigInt(w *io.BinWriter, n *big.Int) {
+	buf := bigint.ToPreallocatedBytes(n, make([]byte, 0, 32))
+	if len(buf) > 32 {
+		w.Err = errors.New("integer is too big")
+		return
+	}
+	// buf is not empty, because zero is always handled by Int.
+	padSize := byte(8 - bits.LeadingZeros8(byte(len(buf)-1)))
+	Opcode(w, opcode.PUSHINT8+opcode.Opcode(padSize))
+	w.WriteBytes(padRight(1<<padSize, buf))
+}
+
 // Array emits array of elements to the given buffer.
 func Array(w *io.BinWriter, es ...interface{}) {
 	for i := len(es) - 1; i >= 0; i-- {
 		switch e := es[i].(type) {
 		case int64:
 			Int(w, e)
+		case *big.Int:
+			if e == nil {
+				Opcode(w, opcode.PUSHNULL)
+			} else {
+				BigInt(w, e)
+			}
 		case string:
 			String(w, e)
 		case util.Uint160:
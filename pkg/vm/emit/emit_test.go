@@ -3,6 +3,7 @@ package emit
 import (
 	"encoding/binary"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -83,6 +84,43 @@ func TestEmitInt(t *testing.T) {
 	})
 }
 
+func TestEmitBigInt(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		BigInt(buf.BinWriter, big.NewInt(10))
+		require.NoError(t, buf.Err)
+		assert.Equal(t, []byte{byte(opcode.PUSH10)}, buf.Bytes())
+	})
+
+	t.Run("16-byte int", func(t *testing.T) {
+		num := new(big.Int).Lsh(big.NewInt(1), 100)
+		buf := io.NewBufBinWriter()
+		BigInt(buf.BinWriter, num)
+		require.NoError(t, buf.Err)
+		result := buf.Bytes()
+		assert.Equal(t, 17, len(result))
+		assert.EqualValues(t, opcode.PUSHINT128, result[0])
+		assert.Equal(t, 0, num.Cmp(bigint.FromBytes(result[1:])))
+	})
+
+	t.Run("negative 32-byte int", func(t *testing.T) {
+		num := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+		buf := io.NewBufBinWriter()
+		BigInt(buf.BinWriter, num)
+		require.NoError(t, buf.Err)
+		result := buf.Bytes()
+		assert.Equal(t, 33, len(result))
+		assert.EqualValues(t, opcode.PUSHINT256, result[0])
+		assert.Equal(t, 0, num.Cmp(bigint.FromBytes(result[1:])))
+	})
+
+	t.Run("too big", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		BigInt(buf.BinWriter, new(big.Int).Lsh(big.NewInt(1), 255))
+		require.Error(t, buf.Err)
+	})
+}
+
 func getSlice(n int) []byte {
 	data := make([]byte, n)
 	for i := range data {
@@ -158,6 +196,13 @@ func TestEmitArray(t *testing.T) {
 		assert.EqualValues(t, opcode.PUSHNULL, res[11])
 	})
 
+	t.Run("big integers", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		Array(buf.BinWriter, big.NewInt(1), (*big.Int)(nil))
+		require.NoError(t, buf.Err)
+		assert.EqualValues(t, []byte{byte(opcode.PUSHNULL), byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.PACK)}, buf.Bytes())
+	})
+
 	t.Run("empty", func(t *testing.T) {
 		buf := io.NewBufBinWriter()
 		Array(buf.BinWriter)
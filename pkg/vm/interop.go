@@ -259,3 +259,29 @@ func IteratorValues(v *VM) error {
 
 	return nil
 }
+
+// IsIterator returns whether the given stack item is an interop item holding
+// an iterator or an enumerator.
+func IsIterator(item stackitem.Item) bool {
+	iop, ok := item.(*stackitem.Interop)
+	if !ok {
+		return false
+	}
+	_, ok = iop.Value().(enumerator)
+	return ok
+}
+
+// IterateNext advances the iterator (or enumerator) held by the given interop
+// item and returns its next value, it returns false when there are no more
+// values. Values of iterators (that is, everything having keys, including
+// array-based enumerators) are returned as structs of key and value.
+func IterateNext(item stackitem.Item) (stackitem.Item, bool) {
+	e := item.(*stackitem.Interop).Value().(enumerator)
+	if !e.Next() {
+		return nil, false
+	}
+	if iter, ok := e.(iterator); ok {
+		return stackitem.NewStruct([]stackitem.Item{iter.Key(), iter.Value()}), true
+	}
+	return e.Value(), true
+}
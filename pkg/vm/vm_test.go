@@ -649,6 +649,45 @@ func TestIteratorValues(t *testing.T) {
 	require.Equal(t, stackitem.NewBool(true), v.estack.Peek(4).value)
 }
 
+func TestIterateNext(t *testing.T) {
+	arr := []stackitem.Item{stackitem.NewBool(false), stackitem.Make(42)}
+
+	t.Run("NotIterator", func(t *testing.T) {
+		require.False(t, IsIterator(stackitem.NewArray(arr)))
+		require.False(t, IsIterator(stackitem.NewInterop(42)))
+	})
+	t.Run("Iterator", func(t *testing.T) {
+		v := load(getSyscallProg(interopnames.SystemIteratorCreate))
+		v.estack.PushVal(stackitem.NewArray(arr))
+		runVM(t, v)
+		iter := v.estack.Pop().Item()
+		require.True(t, IsIterator(iter))
+		for i := range arr {
+			item, ok := IterateNext(iter)
+			require.True(t, ok)
+			require.Equal(t, stackitem.NewStruct([]stackitem.Item{stackitem.Make(i), arr[i]}), item)
+		}
+		_, ok := IterateNext(iter)
+		require.False(t, ok)
+	})
+	t.Run("Enumerator", func(t *testing.T) {
+		prog := getSyscallProg(interopnames.SystemIteratorCreate)
+		prog = append(prog, getSyscallProg(interopnames.SystemIteratorValues)...)
+		v := load(prog)
+		v.estack.PushVal(stackitem.NewArray(arr))
+		runVM(t, v)
+		enum := v.estack.Pop().Item()
+		require.True(t, IsIterator(enum))
+		for i := range arr {
+			item, ok := IterateNext(enum)
+			require.True(t, ok)
+			require.Equal(t, arr[i], item)
+		}
+		_, ok := IterateNext(enum)
+		require.False(t, ok)
+	})
+}
+
 func getSyscallProg(name string) (prog []byte) {
 	buf := io.NewBufBinWriter()
 	emit.Syscall(buf.BinWriter, name)